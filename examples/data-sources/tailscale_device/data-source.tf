@@ -5,5 +5,27 @@ data "tailscale_device" "sample_device" {
 
 data "tailscale_device" "sample_device2" {
   hostname = "device2"
+  select   = "most_recently_seen"
   wait_for = "60s"
 }
+
+data "tailscale_device" "sample_subnet_router" {
+  hostname = "subnet-router"
+  wait_for = "300s"
+
+  wait_until {
+    authorized           = true
+    connected_to_control = true
+    advertised_routes    = ["10.0.0.0/16"]
+    tags                 = ["tag:subnet-router"]
+    min_client_version   = "1.80.0"
+  }
+}
+
+data "tailscale_device" "sample_device_by_address" {
+  address = "100.64.0.1"
+}
+
+data "tailscale_device" "bastion" {
+  tag = "tag:bastion"
+}
@@ -1,5 +1,6 @@
 data "tailscale_devices" "sample_devices" {
   name_prefix = "example-"
+  sort_by     = "name"
 
   filter {
     name = "isEphemeral"
@@ -10,3 +11,10 @@ data "tailscale_devices" "sample_devices" {
     values = ["tag:server", "tag:test"]
   }
 }
+
+output "sample_device_addresses" {
+  value = {
+    for node_id, device in data.tailscale_devices.sample_devices.devices_by_node_id :
+    node_id => device.addresses
+  }
+}
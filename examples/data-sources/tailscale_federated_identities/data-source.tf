@@ -0,0 +1,13 @@
+data "tailscale_federated_identities" "all" {
+  all = true
+}
+
+# Federated identities that can modify the tailnet policy file.
+data "tailscale_federated_identities" "policy_file" {
+  all    = true
+  scopes = ["policy_file"]
+}
+
+output "github_actions_identities" {
+  value = [for identity in data.tailscale_federated_identities.all.federated_identities : identity.subject if identity.issuer == "https://token.actions.githubusercontent.com"]
+}
@@ -0,0 +1,14 @@
+data "tailscale_oauth_clients" "all" {
+  all = true
+}
+
+# OAuth clients that can modify the tailnet policy file or create auth keys,
+# including clients with the "all" scope.
+data "tailscale_oauth_clients" "privileged" {
+  all    = true
+  scopes = ["policy_file", "auth_keys"]
+}
+
+output "privileged_oauth_clients" {
+  value = [for client in data.tailscale_oauth_clients.privileged.clients : client.id]
+}
@@ -0,0 +1,17 @@
+data "tailscale_subnet_route_overlaps" "all" {}
+
+data "tailscale_subnet_route_overlaps" "routers" {
+  include_advertised = true
+
+  filter {
+    name   = "tags"
+    values = ["tag:router"]
+  }
+}
+
+check "no_unintended_route_overlaps" {
+  assert {
+    condition     = alltrue([for overlap in data.tailscale_subnet_route_overlaps.all.overlaps : overlap.likely_ha_pair])
+    error_message = "Overlapping subnet routes are enabled on devices that are not HA pairs."
+  }
+}
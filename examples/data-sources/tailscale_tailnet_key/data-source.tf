@@ -0,0 +1,14 @@
+variable "auth_key_id" {
+  type = string
+}
+
+data "tailscale_tailnet_key" "shared" {
+  id = var.auth_key_id
+}
+
+check "auth_key_not_expiring" {
+  assert {
+    condition     = timecmp(data.tailscale_tailnet_key.shared.expires_at, timeadd(plantimestamp(), "168h")) > 0
+    error_message = "The shared auth key expires within a week."
+  }
+}
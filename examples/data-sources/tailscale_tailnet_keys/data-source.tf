@@ -0,0 +1,14 @@
+data "tailscale_tailnet_keys" "all" {
+  all = true
+}
+
+# Auth keys tagged for servers that expire within the next 30 days.
+data "tailscale_tailnet_keys" "expiring_server_keys" {
+  key_type        = "auth"
+  tags            = ["tag:server"]
+  expiring_within = "30d"
+}
+
+output "reusable_auth_keys" {
+  value = [for key in data.tailscale_tailnet_keys.all.keys : key.id if key.key_type == "auth" && key.reusable]
+}
@@ -1 +1,12 @@
 data "tailscale_users" "all-users" {}
+
+# Contractors who have not been seen for 60 days, along with their devices.
+data "tailscale_users" "inactive-contractors" {
+  login_name_regex          = "@contractor\\.com$"
+  last_seen_older_than_days = 60
+  include_devices           = true
+}
+
+data "tailscale_users" "suspended-users" {
+  status = "suspended"
+}
@@ -0,0 +1,8 @@
+resource "tailscale_device_approval_rule" "ci_runners" {
+  tags           = ["tag:ci"]
+  hostname_regex = "^ci-runner-\\d+$"
+  os             = "linux"
+
+  # De-authorize the approved devices when this resource is destroyed.
+  deauthorize_on_destroy = true
+}
@@ -8,3 +8,10 @@ resource "tailscale_device_authorization" "sample_authorization" {
   authorized = true
 }
 
+resource "tailscale_device_authorization" "sample_new_device_authorization" {
+  device_id  = "nodeidCNTRL"
+  authorized = true
+
+  # Wait for a device that was just created to register with the tailnet.
+  wait_for_device = "60s"
+}
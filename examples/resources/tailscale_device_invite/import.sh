@@ -0,0 +1,2 @@
+# Device invites can be imported using the invite ID, e.g.,
+terraform import tailscale_device_invite.sample 123456789
@@ -0,0 +1,13 @@
+data "tailscale_device" "bastion" {
+  hostname = "vendor-bastion"
+}
+
+resource "tailscale_device_invite" "vendor" {
+  device_id = data.tailscale_device.bastion.node_id
+  email     = "support@vendor.example.com"
+}
+
+output "vendor_invite_url" {
+  value     = tailscale_device_invite.vendor.invite_url
+  sensitive = true
+}
@@ -0,0 +1,4 @@
+# Device lifecycle can be imported using the node ID (preferred), e.g.,
+terraform import tailscale_device_lifecycle.sample nodeidCNTRL
+# Device lifecycle can be imported using the legacy ID, e.g.,
+terraform import tailscale_device_lifecycle.sample 123456789
@@ -0,0 +1,9 @@
+data "tailscale_device" "sample_device" {
+  hostname = "vm-1"
+  wait_for = "60s"
+}
+
+# Deletes the device from the tailnet when destroyed.
+resource "tailscale_device_lifecycle" "sample_lifecycle" {
+  device_id = data.tailscale_device.sample_device.node_id
+}
@@ -21,3 +21,22 @@ resource "tailscale_device_subnet_routes" "sample_exit_node" {
     "::/0"
   ]
 }
+
+resource "tailscale_device_subnet_routes" "sample_additive_routes" {
+  # Only manage these routes, leaving routes enabled through autoApprovers or
+  # the admin console untouched.
+  device_id = data.tailscale_device.sample_device.node_id
+  mode      = "additive"
+  routes = [
+    "192.168.0.0/24"
+  ]
+}
+
+resource "tailscale_device_subnet_routes" "sample_required_routes" {
+  # Fail the plan if the device isn't advertising these routes yet.
+  device_id          = data.tailscale_device.sample_device.node_id
+  require_advertised = true
+  routes = [
+    "172.16.0.0/16"
+  ]
+}
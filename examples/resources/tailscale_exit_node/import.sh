@@ -0,0 +1,2 @@
+# Exit node approval can be imported using the node ID, e.g.,
+terraform import tailscale_exit_node.sample nodeidCNTRL
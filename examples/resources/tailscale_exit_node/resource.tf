@@ -0,0 +1,7 @@
+data "tailscale_device" "sample_device" {
+  name = "device.example.com"
+}
+
+resource "tailscale_exit_node" "sample_exit_node" {
+  device_id = data.tailscale_device.sample_device.node_id
+}
@@ -8,3 +8,20 @@ resource "tailscale_federated_identity" "example_federated_identity" {
     repo_name = "example-repo-name"
   }
 }
+
+# A federated identity for GitHub Actions jobs that deploy to the production
+# environment of a repository. The plan fails if the issuer's OIDC discovery
+# document or signing keys cannot be fetched.
+resource "tailscale_federated_identity" "github_actions" {
+  description     = "GitHub Actions ACL deploys"
+  scopes          = ["policy_file"]
+  validate_issuer = "error"
+
+  preset {
+    github_actions {
+      repository  = "example/acls"
+      ref         = "refs/heads/main"
+      environment = "production"
+    }
+  }
+}
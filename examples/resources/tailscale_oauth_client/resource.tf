@@ -3,3 +3,20 @@ resource "tailscale_oauth_client" "sample_client" {
   scopes      = ["all:read"]
   tags        = ["tag:test"]
 }
+
+# A client whose secret is rotated every quarter. The client is replaced on
+# rotation, so its ID changes as well.
+resource "time_rotating" "quarterly" {
+  rotation_days = 90
+}
+
+resource "tailscale_oauth_client" "rotated_client" {
+  description      = "rotated client"
+  scopes           = ["auth_keys"]
+  tags             = ["tag:test"]
+  rotation_trigger = time_rotating.quarterly.id
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
@@ -0,0 +1,12 @@
+resource "tailscale_stale_device_cleanup" "ci_runners" {
+  last_seen_days   = 30
+  tags             = ["tag:ci"]
+  exclude_node_ids = ["nodeidCNTRL"]
+
+  # Only report the devices that would be deleted in candidate_node_ids.
+  dry_run = true
+}
+
+output "stale_ci_runners" {
+  value = tailscale_stale_device_cleanup.ci_runners.candidate_node_ids
+}
@@ -5,3 +5,22 @@ resource "tailscale_tailnet_key" "sample_key" {
   expiry        = 3600
   description   = "Sample key"
 }
+
+# A key for an autoscaling group that is rotated a week before it expires,
+# and whenever the launch template changes.
+resource "tailscale_tailnet_key" "autoscaling_key" {
+  reusable        = true
+  ephemeral       = true
+  preauthorized   = true
+  tags            = ["tag:autoscaled"]
+  expiry_duration = "90d"
+  rotate_before   = "7d"
+
+  keepers = {
+    launch_template_version = "3"
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
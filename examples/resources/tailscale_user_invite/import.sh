@@ -0,0 +1,2 @@
+# User invites can be imported using the invite ID, e.g.,
+terraform import tailscale_user_invite.sample 123456789
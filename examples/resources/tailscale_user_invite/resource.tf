@@ -0,0 +1,8 @@
+resource "tailscale_user_invite" "contractor" {
+  email = "contractor@example.com"
+  role  = "member"
+}
+
+output "contractor_user_id" {
+  value = tailscale_user_invite.contractor.user_id
+}
@@ -0,0 +1,2 @@
+# User roles can be imported using the user ID, e.g.,
+terraform import tailscale_user_role.sample 123456789
@@ -0,0 +1,8 @@
+data "tailscale_user" "oncall" {
+  login_name = "oncall@example.com"
+}
+
+resource "tailscale_user_role" "oncall" {
+  user_id = data.tailscale_user.oncall.id
+  role    = "admin"
+}
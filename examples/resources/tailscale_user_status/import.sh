@@ -0,0 +1,2 @@
+# User statuses can be imported using the user ID, e.g.,
+terraform import tailscale_user_status.sample 123456789
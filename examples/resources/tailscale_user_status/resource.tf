@@ -0,0 +1,8 @@
+data "tailscale_user" "contractor" {
+  login_name = "contractor@example.com"
+}
+
+resource "tailscale_user_status" "contractor" {
+  user_id = data.tailscale_user.contractor.id
+  status  = "suspended"
+}
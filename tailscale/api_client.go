@@ -0,0 +1,94 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"tailscale.com/client/tailscale/v2"
+)
+
+// apiRequest sends a request to an endpoint of the Tailscale API that is not
+// covered by the API client, such as device and user invites. The path
+// elements are escaped and appended to /api/v2. If body is not nil, it is sent
+// as JSON, and if out is not nil, the JSON response is decoded into it.
+//
+// Errors returned by the API are returned as a [tailscale.APIError], so that
+// they can be checked with functions like [tailscale.IsNotFound].
+func apiRequest(ctx context.Context, client *tailscale.Client, method string, body, out any, pathElements ...string) error {
+	// Retrieving any resource from the client initializes its base URL, user
+	// agent and authenticated HTTP client.
+	client.Users()
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	elems := []string{"api", "v2"}
+	for _, elem := range pathElements {
+		elems = append(elems, url.PathEscape(elem))
+	}
+	uri := client.BaseURL.JoinPath(elems...)
+	req, err := http.NewRequestWithContext(ctx, method, uri.String(), reqBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if client.UserAgent != "" {
+		req.Header.Set("User-Agent", client.UserAgent)
+	}
+	if client.APIKey != "" {
+		req.SetBasicAuth(client.APIKey, "")
+	}
+
+	res, err := client.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		apiErr := tailscale.APIError{Message: http.StatusText(res.StatusCode)}
+		// Not every error response has a JSON body, so fall back to the status text.
+		_ = json.Unmarshal(resBody, &apiErr)
+		apiErr.Status = res.StatusCode
+		return apiErr
+	}
+
+	if out == nil || len(bytes.TrimSpace(resBody)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(resBody, out); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", uri.Path, err)
+	}
+	return nil
+}
+
+// tailnetPath returns the path elements of the given endpoint below the
+// client's tailnet, for use with [apiRequest].
+func tailnetPath(client *tailscale.Client, pathElements ...string) []string {
+	// Retrieving any resource from the client defaults the tailnet to "-".
+	client.Users()
+	return append([]string{"tailnet", client.Tailnet}, pathElements...)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"tailscale.com/client/tailscale/v2"
+)
+
+func TestAPIRequest(t *testing.T) {
+	t.Parallel()
+
+	var gotMethod, gotPath, gotEscapedPath, gotAPIKey string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath, gotEscapedPath = r.Method, r.URL.Path, r.URL.EscapedPath()
+		gotAPIKey, _, _ = r.BasicAuth()
+		if r.ContentLength > 0 {
+			_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		}
+
+		switch r.URL.Path {
+		case "/api/v2/tailnet/example.com/things":
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": "thing1"})
+		case "/api/v2/things/a/b?c":
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": "a/b?c"})
+		case "/api/v2/things/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "not found"})
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	baseURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &tailscale.Client{BaseURL: baseURL, APIKey: "tskey-api-test", Tailnet: "example.com"}
+
+	var out struct {
+		ID string `json:"id"`
+	}
+	err = apiRequest(context.Background(), client, http.MethodPost, map[string]string{"name": "thing"}, &out, tailnetPath(client, "things")...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotMethod != http.MethodPost || gotPath != "/api/v2/tailnet/example.com/things" {
+		t.Errorf("want POST /api/v2/tailnet/example.com/things, got %s %s", gotMethod, gotPath)
+	}
+	if gotAPIKey != "tskey-api-test" {
+		t.Errorf("want API key to be sent, got %q", gotAPIKey)
+	}
+	if gotBody["name"] != "thing" {
+		t.Errorf("want request body to be sent, got %v", gotBody)
+	}
+	if out.ID != "thing1" {
+		t.Errorf("want response to be decoded, got %+v", out)
+	}
+
+	err = apiRequest(context.Background(), client, http.MethodGet, nil, &out, "things", "a/b?c")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "/api/v2/things/a%2Fb%3Fc"; gotEscapedPath != want {
+		t.Errorf("want path elements to be escaped as %s, got %s", want, gotEscapedPath)
+	}
+
+	err = apiRequest(context.Background(), client, http.MethodGet, nil, &out, "things", "missing")
+	if !tailscale.IsNotFound(err) {
+		t.Errorf("want not found error, got %v", err)
+	}
+
+	err = apiRequest(context.Background(), client, http.MethodDelete, nil, nil, "things", "broken")
+	if err == nil {
+		t.Error("want error for internal server error response, got nil")
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ basetypes.StringTypable                    = cidrType{}
+	_ basetypes.StringValuableWithSemanticEquals = cidrValue{}
+)
+
+// cidrType is a string type for CIDR prefixes that treats prefixes as equal if
+// they have the same masked form, e.g. `10.0.0.1/24` and `10.0.0.0/24`. This
+// avoids endless drift when the configured prefix has host bits set, as the
+// API only ever returns the masked form.
+type cidrType struct {
+	basetypes.StringType
+}
+
+func (t cidrType) Equal(o attr.Type) bool {
+	other, ok := o.(cidrType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t cidrType) String() string {
+	return "cidrType"
+}
+
+func (t cidrType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return cidrValue{StringValue: in}, nil
+}
+
+func (t cidrType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return cidrValue{StringValue: stringValue}, nil
+}
+
+func (t cidrType) ValueType(_ context.Context) attr.Value {
+	return cidrValue{}
+}
+
+// cidrValue is a value of [cidrType].
+type cidrValue struct {
+	basetypes.StringValue
+}
+
+func (v cidrValue) Equal(o attr.Value) bool {
+	other, ok := o.(cidrValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v cidrValue) Type(_ context.Context) attr.Type {
+	return cidrType{}
+}
+
+func (v cidrValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, ok := newValuable.(cidrValue)
+	if !ok {
+		return false, nil
+	}
+	return normalizeCIDR(v.ValueString()) == normalizeCIDR(newValue.ValueString()), nil
+}
+
+// normalizeCIDR returns the masked form of the given CIDR prefix, or the prefix
+// unchanged if it cannot be parsed.
+func normalizeCIDR(prefix string) string {
+	parsed, err := netip.ParsePrefix(prefix)
+	if err != nil {
+		return prefix
+	}
+	return parsed.Masked().String()
+}
+
+// normalizeCIDRs returns the masked forms of the given CIDR prefixes.
+func normalizeCIDRs(prefixes []string) []string {
+	normalized := make([]string, len(prefixes))
+	for i, prefix := range prefixes {
+		normalized[i] = normalizeCIDR(prefix)
+	}
+	return normalized
+}
@@ -0,0 +1,44 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCIDRValueSemanticEquals(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		prior    string
+		proposed string
+		want     bool
+	}{
+		{name: "identical", prior: "10.0.0.0/24", proposed: "10.0.0.0/24", want: true},
+		{name: "host-bits-set", prior: "10.0.0.1/24", proposed: "10.0.0.0/24", want: true},
+		{name: "ipv6-host-bits-set", prior: "fd7a:115c:a1e0::1/48", proposed: "fd7a:115c:a1e0::/48", want: true},
+		{name: "different-prefix-length", prior: "10.0.0.0/24", proposed: "10.0.0.0/16", want: false},
+		{name: "different-network", prior: "10.0.0.0/24", proposed: "10.0.1.0/24", want: false},
+		{name: "unparseable", prior: "not-a-cidr", proposed: "not-a-cidr", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prior := cidrValue{StringValue: types.StringValue(tt.prior)}
+			proposed := cidrValue{StringValue: types.StringValue(tt.proposed)}
+
+			got, diags := prior.StringSemanticEquals(context.Background(), proposed)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if got != tt.want {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -7,8 +7,13 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -16,6 +21,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"tailscale.com/client/tailscale/v2"
+	"tailscale.com/util/cmpver"
 )
 
 var (
@@ -34,7 +40,19 @@ type singleDeviceDataSource struct {
 type singleDeviceDataSourceModel struct {
 	deviceDataSourceModel
 
-	WaitFor types.String `tfsdk:"wait_for"`
+	Address   types.String    `tfsdk:"address"`
+	Tag       types.String    `tfsdk:"tag"`
+	Select    types.String    `tfsdk:"select"`
+	WaitFor   types.String    `tfsdk:"wait_for"`
+	WaitUntil *waitUntilModel `tfsdk:"wait_until"`
+}
+
+type waitUntilModel struct {
+	Authorized         types.Bool   `tfsdk:"authorized"`
+	ConnectedToControl types.Bool   `tfsdk:"connected_to_control"`
+	AdvertisedRoutes   types.Set    `tfsdk:"advertised_routes"`
+	Tags               types.Set    `tfsdk:"tags"`
+	MinClientVersion   types.String `tfsdk:"min_client_version"`
 }
 
 // Metadata defines the data source name as it appears in Terraform configurations.
@@ -49,13 +67,38 @@ func (d singleDeviceDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 			Description: "The full name of the device (e.g. `hostname.domain.ts.net`)",
 			Optional:    true,
 			Validators: []validator.String{
-				stringvalidator.ExactlyOneOf(path.MatchRoot("name"), path.MatchRoot("hostname")),
+				stringvalidator.ExactlyOneOf(
+					path.MatchRoot("name"),
+					path.MatchRoot("hostname"),
+					path.MatchRoot("address"),
+					path.MatchRoot("node_key"),
+					path.MatchRoot("node_id"),
+					path.MatchRoot("tag"),
+				),
 			},
 		},
 		"hostname": schema.StringAttribute{
 			Description: "The short hostname of the device",
 			Optional:    true,
 		},
+		"address": schema.StringAttribute{
+			Description: "One of the Tailscale IP addresses of the device (e.g. `100.64.0.1`)",
+			Optional:    true,
+			Validators: []validator.String{
+				ipAddressValidator{},
+			},
+		},
+		"tag": schema.StringAttribute{
+			Description: "A tag applied to the device (e.g. `tag:bastion`). Useful for singleton devices that are identified by a unique tag.",
+			Optional:    true,
+		},
+		"select": schema.StringAttribute{
+			Description: "How to choose a device when the lookup matches several devices. Valid values are `error`, `most_recently_seen`, `most_recently_created` and `only_connected`. Defaults to `error`, which fails the lookup and lists the node IDs of the matching devices. `only_connected` picks the single matching device that is connected to the control server.",
+			Optional:    true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(deviceSelectModes...),
+			},
+		},
 		"wait_for": schema.StringAttribute{
 			Description: "If specified, the provider will make multiple attempts to obtain the data source until the wait_for duration is reached. Retries are made every second so this value should be greater than 1s",
 			Optional:    true,
@@ -66,9 +109,56 @@ func (d singleDeviceDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 	}
 	maps.Copy(attributes, deviceSchema)
 
+	// The node ID and node key can also be used to look up the device.
+	attributes["node_id"] = schema.StringAttribute{
+		Description: "The preferred indentifier for a device. Can be set to look up the device by its node ID.",
+		Optional:    true,
+		Computed:    true,
+	}
+	attributes["node_key"] = schema.StringAttribute{
+		Description: "The node key of the device. Can be set to look up the device by its node key (e.g. `nodekey:abc123`).",
+		Optional:    true,
+		Computed:    true,
+	}
+
 	resp.Schema = schema.Schema{
 		Description: "The device data source describes a single device in a tailnet",
 		Attributes:  attributes,
+		Blocks: map[string]schema.Block{
+			"wait_until": schema.SingleNestedBlock{
+				Description: "If specified, the device must also satisfy all of the given conditions before the data source is read. The conditions are checked on every attempt made within the wait_for duration.",
+				Attributes: map[string]schema.Attribute{
+					"authorized": schema.BoolAttribute{
+						Description: "Wait until the device is authorized to access the tailnet",
+						Optional:    true,
+					},
+					"connected_to_control": schema.BoolAttribute{
+						Description: "Wait until the device is connected to the control server",
+						Optional:    true,
+					},
+					"advertised_routes": schema.SetAttribute{
+						Description: "Wait until the device is advertising all of the given subnet routes",
+						Optional:    true,
+						ElementType: types.StringType,
+						Validators: []validator.Set{
+							setvalidator.ValueStringsAre(cidrValidator{}),
+						},
+					},
+					"tags": schema.SetAttribute{
+						Description: "Wait until all of the given tags are applied to the device",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"min_client_version": schema.StringAttribute{
+						Description: "Wait until the device runs at least the given Tailscale client version (e.g. `1.80.0`)",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(clientVersionRegexp, "must be a version number such as `1.80.0`"),
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -80,17 +170,45 @@ func (d singleDeviceDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	var filter tailscale.ListDevicesOptions
+	// Routes, client connectivity and posture identity are only returned
+	// when requesting all fields.
+	opts := []tailscale.ListDevicesOptions{tailscale.WithFields(tailscale.IncludeFieldsAll)}
 	var filterDesc string
 
-	if !device.Name.IsNull() {
-		filter = tailscale.WithFilter("name", []string{device.Name.ValueString()})
-		filterDesc = fmt.Sprintf("name=%q", device.Name.ValueString())
-	}
+	// Lookups that the API cannot filter on are matched against the listed
+	// devices instead.
+	match := func(*tailscale.Device) bool { return true }
 
-	if !device.Hostname.IsNull() {
-		filter = tailscale.WithFilter("hostname", []string{device.Hostname.ValueString()})
+	switch {
+	case !device.Name.IsNull():
+		opts = append(opts, tailscale.WithFilter("name", []string{device.Name.ValueString()}))
+		filterDesc = fmt.Sprintf("name=%q", device.Name.ValueString())
+	case !device.Hostname.IsNull():
+		opts = append(opts, tailscale.WithFilter("hostname", []string{device.Hostname.ValueString()}))
 		filterDesc = fmt.Sprintf("hostname=%q", device.Hostname.ValueString())
+	case !device.Address.IsNull():
+		address, err := netip.ParseAddr(device.Address.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to parse address", err.Error())
+			return
+		}
+		match = func(d *tailscale.Device) bool {
+			return slices.ContainsFunc(d.Addresses, func(a string) bool {
+				parsed, err := netip.ParseAddr(a)
+				return err == nil && parsed == address
+			})
+		}
+		filterDesc = fmt.Sprintf("address=%q", device.Address.ValueString())
+	case !device.NodeKey.IsNull():
+		match = func(d *tailscale.Device) bool { return d.NodeKey == device.NodeKey.ValueString() }
+		filterDesc = fmt.Sprintf("node_key=%q", device.NodeKey.ValueString())
+	case !device.NodeID.IsNull():
+		match = func(d *tailscale.Device) bool { return d.NodeID == device.NodeID.ValueString() }
+		filterDesc = fmt.Sprintf("node_id=%q", device.NodeID.ValueString())
+	case !device.Tag.IsNull():
+		opts = append(opts, tailscale.WithFilter("tags", []string{device.Tag.ValueString()}))
+		match = func(d *tailscale.Device) bool { return slices.Contains(d.Tags, device.Tag.ValueString()) }
+		filterDesc = fmt.Sprintf("tag=%q", device.Tag.ValueString())
 	}
 
 	var deadline time.Duration
@@ -105,16 +223,34 @@ func (d singleDeviceDataSource) Read(ctx context.Context, req datasource.ReadReq
 
 	var selected *tailscale.Device
 	poll := func(ctx context.Context) error {
-		devices, err := d.Client.Devices().List(ctx, filter)
+		listed, err := d.Client.Devices().List(ctx, opts...)
 		if err != nil {
 			return err
 		}
 
+		var devices []tailscale.Device
+		for _, candidate := range listed {
+			if match(&candidate) {
+				devices = append(devices, candidate)
+			}
+		}
+
 		if len(devices) == 0 {
 			return fmt.Errorf("could not find device with %s", filterDesc)
 		}
 
-		selected = &devices[0]
+		candidate, err := selectDevice(devices, device.Select.ValueString())
+		if err != nil {
+			return fmt.Errorf("%d devices match %s: %w", len(devices), filterDesc, err)
+		}
+
+		if device.WaitUntil != nil {
+			if err := device.WaitUntil.check(ctx, candidate); err != nil {
+				return err
+			}
+		}
+
+		selected = candidate
 		return nil
 	}
 
@@ -134,6 +270,128 @@ func (d singleDeviceDataSource) Read(ctx context.Context, req datasource.ReadReq
 	resp.Diagnostics.Append(resp.State.Set(ctx, device)...)
 }
 
+// deviceSelectModes are the valid values of the select attribute.
+var deviceSelectModes = []string{"error", "most_recently_seen", "most_recently_created", "only_connected"}
+
+// selectDevice chooses a single device out of the non-empty list of devices
+// matching a lookup, according to the given select mode. An empty mode is
+// treated as "error".
+func selectDevice(devices []tailscale.Device, mode string) (*tailscale.Device, error) {
+	if len(devices) == 1 && mode != "only_connected" {
+		return &devices[0], nil
+	}
+
+	var compare func(a, b *tailscale.Device) int
+	switch mode {
+	case "most_recently_seen":
+		compare = func(a, b *tailscale.Device) int {
+			if c := compareLastSeen(a, b); c != 0 {
+				return c
+			}
+			return a.Created.Compare(b.Created.Time)
+		}
+	case "most_recently_created":
+		compare = func(a, b *tailscale.Device) int {
+			return a.Created.Compare(b.Created.Time)
+		}
+	case "only_connected":
+		var connected []tailscale.Device
+		for _, device := range devices {
+			if device.ConnectedToControl {
+				connected = append(connected, device)
+			}
+		}
+		switch len(connected) {
+		case 0:
+			return nil, fmt.Errorf("none of them are connected to control (node IDs: %s)", joinNodeIDs(devices))
+		case 1:
+			return &connected[0], nil
+		default:
+			return nil, fmt.Errorf("%d of them are connected to control (node IDs: %s); set select to choose between them", len(connected), joinNodeIDs(connected))
+		}
+	default:
+		return nil, fmt.Errorf("node IDs: %s; set select to choose between them", joinNodeIDs(devices))
+	}
+
+	selected := &devices[0]
+	for i := range devices[1:] {
+		device := &devices[i+1]
+		// Ties are broken by node ID so that the choice is deterministic
+		// regardless of the order in which the API returns devices.
+		if c := compare(device, selected); c > 0 || (c == 0 && device.NodeID > selected.NodeID) {
+			selected = device
+		}
+	}
+	return selected, nil
+}
+
+// joinNodeIDs returns the sorted node IDs of the given devices as a
+// comma-separated list.
+func joinNodeIDs(devices []tailscale.Device) string {
+	nodeIDs := make([]string, len(devices))
+	for i, device := range devices {
+		nodeIDs[i] = device.NodeID
+	}
+	slices.Sort(nodeIDs)
+	return strings.Join(nodeIDs, ", ")
+}
+
+// clientVersionRegexp matches the version numbers accepted by min_client_version.
+var clientVersionRegexp = regexp.MustCompile(`^\d+(\.\d+){0,2}$`)
+
+// check returns an error listing the conditions that the device does not yet
+// satisfy, or nil if all of them are met.
+func (w *waitUntilModel) check(ctx context.Context, device *tailscale.Device) error {
+	var unmet []string
+
+	if w.Authorized.ValueBool() && !device.Authorized {
+		unmet = append(unmet, "authorized")
+	}
+
+	if w.ConnectedToControl.ValueBool() && !device.ConnectedToControl {
+		unmet = append(unmet, "connected_to_control")
+	}
+
+	var routes []string
+	w.AdvertisedRoutes.ElementsAs(ctx, &routes, false)
+	if missing := missingElements(normalizeCIDRs(routes), normalizeCIDRs(device.AdvertisedRoutes)); len(missing) > 0 {
+		unmet = append(unmet, fmt.Sprintf("advertised_routes (missing %s)", strings.Join(missing, ", ")))
+	}
+
+	var tags []string
+	w.Tags.ElementsAs(ctx, &tags, false)
+	if missing := missingElements(tags, device.Tags); len(missing) > 0 {
+		unmet = append(unmet, fmt.Sprintf("tags (missing %s)", strings.Join(missing, ", ")))
+	}
+
+	if minVersion := w.MinClientVersion.ValueString(); minVersion != "" {
+		// Client versions have a build suffix, e.g. 1.80.0-t0123456789-gabcdef,
+		// which must not take part in the comparison.
+		version, _, _ := strings.Cut(device.ClientVersion, "-")
+		if version == "" || cmpver.Less(version, minVersion) {
+			unmet = append(unmet, fmt.Sprintf("min_client_version (running %q)", device.ClientVersion))
+		}
+	}
+
+	if len(unmet) > 0 {
+		return fmt.Errorf("device %s does not yet satisfy wait_until conditions: %s", device.NodeID, strings.Join(unmet, "; "))
+	}
+	return nil
+}
+
+// missingElements returns the elements of want that are not present in got,
+// in sorted order.
+func missingElements(want, got []string) []string {
+	var missing []string
+	for _, w := range want {
+		if !slices.Contains(got, w) {
+			missing = append(missing, w)
+		}
+	}
+	slices.Sort(missing)
+	return missing
+}
+
 // retryWithDeadline calls fn once. If fn errors and maxWait and retryInterval are positive, it retries fn until fn
 // succeeds or maxWait elapses, waiting for the duration of retryInterval between attempts.
 func retryWithDeadline(ctx context.Context, fn func(context.Context) error, maxWait time.Duration, retryInterval time.Duration) error {
@@ -12,6 +12,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/stretchr/testify/assert"
 	"tailscale.com/client/tailscale/v2"
@@ -125,6 +128,74 @@ func TestDeviceToMap_LastSeenNil(t *testing.T) {
 	assert.Equal(t, dev.TailnetLockKey, m["tailnet_lock_key"].(string))
 }
 
+func TestToDeviceDataSourceModel_RoutesAndConnectivity(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	dev := &tsclient.Device{
+		NodeID:           "node-123",
+		AdvertisedRoutes: []string{"10.0.0.0/24", "0.0.0.0/0", "::/0"},
+		EnabledRoutes:    []string{"0.0.0.0/0", "::/0"},
+		ClientConnectivity: &tsclient.ClientConnectivity{
+			Endpoints:             []string{"203.0.113.1:41641"},
+			DERP:                  "nyc",
+			MappingVariesByDestIP: true,
+			DERPLatency: map[string]tsclient.DERPRegion{
+				"New York City": {Preferred: true, LatencyMilliseconds: 12.5},
+			},
+			ClientSupports: tsclient.ClientSupports{IPV6: true, UDP: true},
+		},
+		PostureIdentity: &tsclient.DevicePostureIdentity{
+			SerialNumbers: []string{"ABC123"},
+		},
+	}
+
+	m, diags := toDeviceDataSourceModel(ctx, dev)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var advertised, enabled []string
+	m.AdvertisedRoutes.ElementsAs(ctx, &advertised, false)
+	m.EnabledRoutes.ElementsAs(ctx, &enabled, false)
+	assert.ElementsMatch(t, dev.AdvertisedRoutes, advertised)
+	assert.ElementsMatch(t, dev.EnabledRoutes, enabled)
+	assert.True(t, m.IsExitNode.ValueBool())
+
+	var connectivity clientConnectivityModel
+	if diags := m.ClientConnectivity.As(ctx, &connectivity, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	assert.Equal(t, clientConnectivityModel{
+		Endpoints:             []string{"203.0.113.1:41641"},
+		DERP:                  "nyc",
+		MappingVariesByDestIP: true,
+		DERPLatency: map[string]derpLatencyModel{
+			"New York City": {Preferred: true, LatencyMs: 12.5},
+		},
+		ClientSupports: clientSupportsModel{IPv6: true, UDP: true},
+	}, connectivity)
+
+	var posture postureIdentityModel
+	if diags := m.PostureIdentity.As(ctx, &posture, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	assert.Equal(t, postureIdentityModel{
+		SerialNumbers:     []string{"ABC123"},
+		HardwareAddresses: []string{},
+	}, posture)
+
+	// Devices that only advertise exit node routes are not exit nodes yet,
+	// and devices without connectivity or posture data have null attributes.
+	m, diags = toDeviceDataSourceModel(ctx, &tsclient.Device{AdvertisedRoutes: []string{"0.0.0.0/0", "::/0"}})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	assert.False(t, m.IsExitNode.ValueBool())
+	assert.True(t, m.ClientConnectivity.IsNull())
+	assert.True(t, m.PostureIdentity.IsNull())
+}
+
 func TestDeviceRetry_EventualSuccess(t *testing.T) {
 	const cfg = `
 		data "tailscale_device" "test_device" {
@@ -188,6 +259,268 @@ func TestDeviceRetry_PersistentFailure(t *testing.T) {
 	})
 }
 
+func TestDeviceRetry_WaitUntilConditions(t *testing.T) {
+	const cfg = `
+		data "tailscale_device" "test_device" {
+		  hostname = "target"
+		  wait_for = "4s"
+
+		  wait_until {
+		    authorized         = true
+		    tags               = ["tag:server"]
+		    min_client_version = "1.80.0"
+		  }
+		}
+	`
+
+	pending := tsclient.Device{Name: "target.example.ts.net", Hostname: "target", NodeID: "node-123", ClientVersion: "1.78.1-t0123456789"}
+	ready := tsclient.Device{Name: "target.example.ts.net", Hostname: "target", NodeID: "node-123", ClientVersion: "1.80.2-t0123456789", Authorized: true, Tags: []string{"tag:server"}}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.SetResponses([]TestResponse{
+				{Code: http.StatusOK, Body: map[string][]tsclient.Device{"devices": {pending}}},
+				{Code: http.StatusOK, Body: map[string][]tsclient.Device{"devices": {ready}}},
+			})
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tailscale_device.test_device", "authorized", "true"),
+					resource.TestCheckResourceAttr("data.tailscale_device.test_device", "client_version", "1.80.2-t0123456789"),
+				),
+			},
+		},
+	})
+}
+
+func TestDeviceRetry_WaitUntilReportsUnmetConditions(t *testing.T) {
+	const cfg = `
+		data "tailscale_device" "test_device" {
+		  hostname = "target"
+		  wait_for = "2s"
+
+		  wait_until {
+		    authorized           = true
+		    connected_to_control = true
+		  }
+		}
+	`
+
+	pending := tsclient.Device{Name: "target.example.ts.net", Hostname: "target", NodeID: "node-123", ConnectedToControl: true}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.SetResponses([]TestResponse{
+				{Code: http.StatusOK, Body: map[string][]tsclient.Device{"devices": {pending}}},
+			})
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config:      cfg,
+				ExpectError: regexp.MustCompile(`device node-123 does not yet satisfy wait_until conditions: authorized`),
+			},
+		},
+	})
+}
+
+func TestProvider_DataSourceDevice_AmbiguousLookup(t *testing.T) {
+	const cfg = `
+		data "tailscale_device" "test_device" {
+		  hostname = "target"
+		}
+	`
+
+	devices := []tsclient.Device{
+		{Name: "target-1.example.ts.net", Hostname: "target", NodeID: "node-456"},
+		{Name: "target.example.ts.net", Hostname: "target", NodeID: "node-123"},
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.SetResponses([]TestResponse{
+				{Code: http.StatusOK, Body: map[string][]tsclient.Device{"devices": devices}},
+			})
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config:      cfg,
+				ExpectError: regexp.MustCompile(`2 devices match hostname="target": node IDs: node-123, node-456`),
+			},
+		},
+	})
+}
+
+func TestProvider_DataSourceDevice_AlternativeLookups(t *testing.T) {
+	devices := []tsclient.Device{
+		{Name: "web.example.ts.net", Hostname: "web", NodeID: "node-123", NodeKey: "nodekey:123", Addresses: []string{"100.64.0.1", "fd7a:115c:a1e0::1"}, Tags: []string{"tag:web"}},
+		{Name: "bastion.example.ts.net", Hostname: "bastion", NodeID: "node-456", NodeKey: "nodekey:456", Addresses: []string{"100.64.0.2", "fd7a:115c:a1e0::2"}, Tags: []string{"tag:bastion", "tag:ssh"}},
+	}
+
+	tests := []struct {
+		name   string
+		lookup string
+	}{
+		{name: "address", lookup: `address = "100.64.0.2"`},
+		{name: "ipv6-address", lookup: `address = "fd7a:115c:a1e0:0::2"`},
+		{name: "node-key", lookup: `node_key = "nodekey:456"`},
+		{name: "node-id", lookup: `node_id = "node-456"`},
+		{name: "tag", lookup: `tag = "tag:bastion"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest: true,
+				PreCheck: func() {
+					testServer.SetResponses([]TestResponse{
+						{Code: http.StatusOK, Body: map[string][]tsclient.Device{"devices": devices}},
+					})
+				},
+				ProtoV5ProviderFactories: testProviderFactories(t),
+				Steps: []resource.TestStep{
+					{
+						Config: `data "tailscale_device" "test_device" {` + "\n" + tt.lookup + "\n}",
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("data.tailscale_device.test_device", "hostname", "bastion"),
+							resource.TestCheckResourceAttr("data.tailscale_device.test_device", "node_id", "node-456"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestSelectDevice(t *testing.T) {
+	t.Parallel()
+
+	at := func(s string) tsclient.Time {
+		parsed, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tsclient.Time{Time: parsed}
+	}
+	lastSeen := func(s string) *tsclient.Time {
+		v := at(s)
+		return &v
+	}
+
+	devices := []tsclient.Device{
+		{NodeID: "old", Created: at("2024-01-01T00:00:00Z"), LastSeen: lastSeen("2024-06-01T00:00:00Z")},
+		{NodeID: "connected", Created: at("2024-02-01T00:00:00Z"), ConnectedToControl: true},
+		{NodeID: "new", Created: at("2024-03-01T00:00:00Z"), LastSeen: lastSeen("2024-05-01T00:00:00Z")},
+	}
+
+	tests := []struct {
+		name    string
+		devices []tsclient.Device
+		mode    string
+		want    string
+		wantErr string
+	}{
+		{name: "single-device", devices: devices[:1], want: "old"},
+		{name: "default-errors", devices: devices, wantErr: "node IDs: connected, new, old; set select to choose between them"},
+		{name: "error", devices: devices, mode: "error", wantErr: "node IDs: connected, new, old"},
+		{name: "most-recently-seen", devices: devices, mode: "most_recently_seen", want: "connected"},
+		{name: "most-recently-created", devices: devices, mode: "most_recently_created", want: "new"},
+		{name: "only-connected", devices: devices, mode: "only_connected", want: "connected"},
+		{name: "only-connected-none", devices: []tsclient.Device{devices[0], devices[2]}, mode: "only_connected", wantErr: "none of them are connected to control (node IDs: new, old)"},
+		{name: "only-connected-single-disconnected", devices: devices[:1], mode: "only_connected", wantErr: "none of them are connected to control (node IDs: old)"},
+		{
+			name: "only-connected-several",
+			devices: []tsclient.Device{
+				{NodeID: "b", ConnectedToControl: true},
+				{NodeID: "a", ConnectedToControl: true},
+			},
+			mode:    "only_connected",
+			wantErr: "2 of them are connected to control (node IDs: a, b)",
+		},
+		{
+			name: "tie-broken-by-node-id",
+			devices: []tsclient.Device{
+				{NodeID: "b", Created: at("2024-01-01T00:00:00Z")},
+				{NodeID: "c", Created: at("2024-01-01T00:00:00Z")},
+				{NodeID: "a", Created: at("2024-01-01T00:00:00Z")},
+			},
+			mode: "most_recently_created",
+			want: "c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectDevice(tt.devices, tt.mode)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("want error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, got %v", err)
+			}
+			if got.NodeID != tt.want {
+				t.Errorf("want node %q, got %q", tt.want, got.NodeID)
+			}
+		})
+	}
+}
+
+func TestWaitUntilCheck(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	w := &waitUntilModel{
+		Authorized:         types.BoolValue(true),
+		ConnectedToControl: types.BoolNull(),
+		AdvertisedRoutes:   types.SetValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.0/24"), types.StringValue("::/0")}),
+		Tags:               types.SetNull(types.StringType),
+		MinClientVersion:   types.StringValue("1.80.0"),
+	}
+
+	err := w.check(ctx, &tsclient.Device{
+		NodeID:           "node-123",
+		AdvertisedRoutes: []string{"10.0.0.0/24"},
+		ClientVersion:    "1.8.0",
+	})
+	want := `device node-123 does not yet satisfy wait_until conditions: authorized; advertised_routes (missing ::/0); min_client_version (running "1.8.0")`
+	if err == nil || err.Error() != want {
+		t.Fatalf("want error %q, got %v", want, err)
+	}
+
+	err = w.check(ctx, &tsclient.Device{
+		NodeID:           "node-123",
+		Authorized:       true,
+		AdvertisedRoutes: []string{"::/0", "10.0.0.0/24", "0.0.0.0/0"},
+		ClientVersion:    "1.80.0-t0123456789-gabcdef",
+	})
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+
+	// Routes are compared by the prefixes they cover.
+	w = &waitUntilModel{
+		AdvertisedRoutes: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.1/24")}),
+		Tags:             types.SetNull(types.StringType),
+	}
+	err = w.check(ctx, &tsclient.Device{
+		NodeID:           "node-123",
+		AdvertisedRoutes: []string{"10.0.0.0/24"},
+	})
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+}
+
 func TestRetryWithDeadline_SucceedsEventually(t *testing.T) {
 	ctx := context.Background()
 
@@ -252,7 +585,7 @@ func TestProvider_DataSourceDevice_InvalidConfig(t *testing.T) {
 		{
 			Name:        "no-fields",
 			Config:      `data "tailscale_device" "example" {}`,
-			ExpectError: regexp.MustCompile(`No attribute specified when one \(and only one\) of \[name,hostname\] is required`),
+			ExpectError: regexp.MustCompile(`No attribute specified when one \(and only one\) of\s*\[name,hostname,address,node_key,node_id,tag\] is required`),
 		},
 		{
 			Name: "too-many-fields",
@@ -262,7 +595,7 @@ func TestProvider_DataSourceDevice_InvalidConfig(t *testing.T) {
 						hostname = "hostname"
 					}
 				`,
-			ExpectError: regexp.MustCompile(`2 attributes specified when one \(and only one\) of \[name,hostname\] is required`),
+			ExpectError: regexp.MustCompile(`2 attributes specified when one \(and only one\) of\s*\[name,hostname,address,node_key,node_id,tag\] is required`),
 		},
 
 		{
@@ -295,6 +628,48 @@ func TestProvider_DataSourceDevice_InvalidConfig(t *testing.T) {
 			`,
 			ExpectError: regexp.MustCompile(`Attribute wait_for duration must be greater than 1 second, got: 1ms`),
 		},
+		{
+			Name: "name-and-tag",
+			Config: `
+					data "tailscale_device" "example" {
+						name = "hostname.domain.ts.net"
+						tag  = "tag:bastion"
+					}
+				`,
+			ExpectError: regexp.MustCompile(`2 attributes specified when one \(and only one\) of`),
+		},
+		{
+			Name: "invalid-address",
+			Config: `
+				data "tailscale_device" "example" {
+					address = "100.64.0.0/10"
+				}
+			`,
+			ExpectError: regexp.MustCompile(`Attribute address value must be an IP address`),
+		},
+		{
+			Name: "invalid-select",
+			Config: `
+				data "tailscale_device" "example" {
+					hostname = "hostname"
+					select   = "newest"
+				}
+			`,
+			ExpectError: regexp.MustCompile(`Attribute select value must be one of`),
+		},
+		{
+			Name: "invalid-min-client-version",
+			Config: `
+				data "tailscale_device" "example" {
+					name = "hostname.domain.ts.net"
+
+					wait_until {
+						min_client_version = "latest"
+					}
+				}
+			`,
+			ExpectError: regexp.MustCompile(`Attribute wait_until.min_client_version must be a version number`),
+		},
 	}
 
 	runExpectedErrorTests(t, testCases)
@@ -332,5 +707,6 @@ func deviceToMap(device *tailscale.Device) map[string]any {
 		"update_available":            device.UpdateAvailable,
 		"tailnet_lock_error":          device.TailnetLockError,
 		"tailnet_lock_key":            device.TailnetLockKey,
+		"is_exit_node":                isExitNode(device.AdvertisedRoutes) && isExitNode(device.EnabledRoutes),
 	}
 }
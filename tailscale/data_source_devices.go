@@ -4,13 +4,18 @@
 package tailscale
 
 import (
+	"cmp"
 	"context"
 	"maps"
+	"slices"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
-
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"tailscale.com/client/tailscale/v2"
@@ -30,10 +35,14 @@ type multipleDevicesDataSource struct {
 }
 
 type multipleDevicesDataSourceModel struct {
-	ID         types.String            `tfsdk:"id"`
-	NamePrefix types.String            `tfsdk:"name_prefix"`
-	Filters    []filterModel           `tfsdk:"filter"`
-	Devices    []deviceDataSourceModel `tfsdk:"devices"`
+	ID                types.String            `tfsdk:"id"`
+	NamePrefix        types.String            `tfsdk:"name_prefix"`
+	SortBy            types.String            `tfsdk:"sort_by"`
+	Filters           []filterModel           `tfsdk:"filter"`
+	Devices           []deviceDataSourceModel `tfsdk:"devices"`
+	DevicesByNodeID   types.Map               `tfsdk:"devices_by_node_id"`
+	DevicesByHostname types.Map               `tfsdk:"devices_by_hostname"`
+	DeviceCount       types.Int64             `tfsdk:"device_count"`
 }
 
 type filterModel struct {
@@ -46,9 +55,10 @@ func (d multipleDevicesDataSource) Metadata(_ context.Context, req datasource.Me
 	resp.TypeName = req.ProviderTypeName + "_devices"
 }
 
-// Schema defines a schema describing what data is available in the data source response.
-func (d multipleDevicesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
-	nestedDeviceAttributes := map[string]schema.Attribute{
+// nestedDeviceAttributes returns the attributes describing each device listed
+// by the devices data source.
+func nestedDeviceAttributes() map[string]schema.Attribute {
+	attributes := map[string]schema.Attribute{
 		"name": schema.StringAttribute{
 
 			Description: "The full name of the device (e.g. `hostname.domain.ts.net`)",
@@ -59,8 +69,22 @@ func (d multipleDevicesDataSource) Schema(_ context.Context, _ datasource.Schema
 			Computed:    true,
 		},
 	}
-	maps.Copy(nestedDeviceAttributes, deviceSchema)
+	maps.Copy(attributes, deviceSchema)
+	return attributes
+}
+
+// deviceObjectType returns the object type of a device as described by
+// [nestedDeviceAttributes], for use as the element type of collections.
+func deviceObjectType() types.ObjectType {
+	attrTypes := make(map[string]attr.Type)
+	for name, attribute := range nestedDeviceAttributes() {
+		attrTypes[name] = attribute.GetType()
+	}
+	return types.ObjectType{AttrTypes: attrTypes}
+}
 
+// Schema defines a schema describing what data is available in the data source response.
+func (d multipleDevicesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "The devices data source describes a list of devices in a tailnet",
 		Attributes: map[string]schema.Attribute{
@@ -71,28 +95,34 @@ func (d multipleDevicesDataSource) Schema(_ context.Context, _ datasource.Schema
 				Optional:    true,
 				Description: "Filters the device list to elements whose name has the provided prefix",
 			},
-		},
-		Blocks: map[string]schema.Block{
-			"filter": schema.SetNestedBlock{
-				Description: "Filters the device list to elements devices whose fields match the provided values.",
-				NestedObject: schema.NestedBlockObject{
-					Attributes: map[string]schema.Attribute{
-						"name": schema.StringAttribute{
-							Description: "The name must be a top-level device property, e.g. isEphemeral, tags, hostname, etc.",
-							Required:    true,
-						},
-						"values": schema.SetAttribute{
-							Description: "The list of values to filter for. Values are matched as exact matches.",
-							ElementType: types.StringType,
-							Required:    true,
-						},
-					},
+			"sort_by": schema.StringAttribute{
+				Optional:    true,
+				Description: "Sorts the device list in ascending order of the given field. Valid values are `name`, `created` and `last_seen`. Devices that are currently connected are sorted last by `last_seen`. Defaults to the order returned by the Tailscale API.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("name", "created", "last_seen"),
 				},
 			},
+			"devices_by_node_id": schema.MapAttribute{
+				Computed:    true,
+				Description: "The devices in the device list, keyed by their node ID.",
+				ElementType: deviceObjectType(),
+			},
+			"devices_by_hostname": schema.MapAttribute{
+				Computed:    true,
+				Description: "The devices in the device list, keyed by their hostname. If several devices share a hostname, the most recently created device is used, with ties broken by the highest node ID. Devices without a hostname are omitted.",
+				ElementType: deviceObjectType(),
+			},
+			"device_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of devices in the device list.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": deviceFilterBlock("Filters the device list to elements devices whose fields match the provided values."),
 			"devices": schema.ListNestedBlock{
 				Description: "The list of devices in the tailnet",
 				NestedObject: schema.NestedBlockObject{
-					Attributes: nestedDeviceAttributes,
+					Attributes: nestedDeviceAttributes(),
 				},
 			},
 		},
@@ -107,17 +137,11 @@ func (d multipleDevicesDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
-	opts := make([]tailscale.ListDevicesOptions, 0, len(data.Filters))
-	for _, f := range data.Filters {
-		var values []string
-
-		diags := f.Values.ElementsAs(ctx, &values, false)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		opts = append(opts, tailscale.WithFilter(f.Name.ValueString(), values))
+	// Routes, client connectivity and posture identity are only returned
+	// when requesting all fields.
+	opts := append(deviceFilterOptions(ctx, data.Filters, &resp.Diagnostics), tailscale.WithFields(tailscale.IncludeFieldsAll))
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	devices, err := d.Client.Devices().List(ctx, opts...)
@@ -126,11 +150,15 @@ func (d multipleDevicesDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
-	prefix := data.NamePrefix.ValueString()
+	sortDevices(devices, data.SortBy.ValueString())
+
 	data.Devices = make([]deviceDataSourceModel, 0)
+	byNodeID := make(map[string]deviceDataSourceModel)
+	byHostname := make(map[string]deviceDataSourceModel)
+	hostnameOwners := make(map[string]*tailscale.Device)
 
 	for _, dev := range devices {
-		if prefix != "" && !strings.HasPrefix(dev.Name, prefix) {
+		if !strings.HasPrefix(dev.Name, data.NamePrefix.ValueString()) {
 			continue
 		}
 
@@ -141,8 +169,113 @@ func (d multipleDevicesDataSource) Read(ctx context.Context, req datasource.Read
 		}
 
 		data.Devices = append(data.Devices, deviceModel)
+		byNodeID[dev.NodeID] = deviceModel
+
+		if dev.Hostname == "" {
+			continue
+		}
+		if owner, ok := hostnameOwners[dev.Hostname]; !ok || preferForHostname(&dev, owner) {
+			hostnameOwners[dev.Hostname] = &dev
+			byHostname[dev.Hostname] = deviceModel
+		}
 	}
 
+	var diags diag.Diagnostics
+	data.DevicesByNodeID, diags = types.MapValueFrom(ctx, deviceObjectType(), byNodeID)
+	resp.Diagnostics.Append(diags...)
+	data.DevicesByHostname, diags = types.MapValueFrom(ctx, deviceObjectType(), byHostname)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.DeviceCount = types.Int64Value(int64(len(data.Devices)))
+
 	data.ID = types.StringValue(createUUID())
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// deviceFilterBlock returns the schema of the filter blocks that are passed to
+// the API when listing devices.
+func deviceFilterBlock(description string) schema.SetNestedBlock {
+	return schema.SetNestedBlock{
+		Description: description,
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Description: "The name must be a top-level device property, e.g. isEphemeral, tags, hostname, etc.",
+					Required:    true,
+				},
+				"values": schema.SetAttribute{
+					Description: "The list of values to filter for. Values are matched as exact matches.",
+					ElementType: types.StringType,
+					Required:    true,
+				},
+			},
+		},
+	}
+}
+
+// deviceFilterOptions returns the options for listing only the devices
+// matching the given filters.
+func deviceFilterOptions(ctx context.Context, filters []filterModel, diags *diag.Diagnostics) []tailscale.ListDevicesOptions {
+	opts := make([]tailscale.ListDevicesOptions, 0, len(filters))
+	for _, f := range filters {
+		var values []string
+		diags.Append(f.Values.ElementsAs(ctx, &values, false)...)
+		opts = append(opts, tailscale.WithFilter(f.Name.ValueString(), values))
+	}
+	return opts
+}
+
+// sortDevices sorts devices in place, in ascending order of the field named by
+// sortBy. Devices that compare equal are ordered by node ID. If sortBy is empty,
+// devices are left in the order returned by the API.
+func sortDevices(devices []tailscale.Device, sortBy string) {
+	var compare func(a, b tailscale.Device) int
+	switch sortBy {
+	case "name":
+		compare = func(a, b tailscale.Device) int {
+			return cmp.Compare(a.Name, b.Name)
+		}
+	case "created":
+		compare = func(a, b tailscale.Device) int {
+			return a.Created.Compare(b.Created.Time)
+		}
+	case "last_seen":
+		compare = func(a, b tailscale.Device) int {
+			return compareLastSeen(&a, &b)
+		}
+	default:
+		return
+	}
+
+	slices.SortStableFunc(devices, func(a, b tailscale.Device) int {
+		return cmp.Or(compare(a, b), cmp.Compare(a.NodeID, b.NodeID))
+	})
+}
+
+// compareLastSeen compares the times at which devices a and b were last seen.
+// Devices that are connected to control, in which case LastSeen is not set,
+// are considered to have been seen most recently.
+func compareLastSeen(a, b *tailscale.Device) int {
+	switch {
+	case a.LastSeen == nil && b.LastSeen == nil:
+		return 0
+	case a.LastSeen == nil:
+		return 1
+	case b.LastSeen == nil:
+		return -1
+	default:
+		return a.LastSeen.Compare(b.LastSeen.Time)
+	}
+}
+
+// preferForHostname reports whether candidate should replace current as the
+// device listed under their shared hostname: the most recently created device
+// wins, with ties broken by the highest node ID.
+func preferForHostname(candidate, current *tailscale.Device) bool {
+	if c := candidate.Created.Compare(current.Created.Time); c != 0 {
+		return c > 0
+	}
+	return candidate.NodeID > current.NodeID
+}
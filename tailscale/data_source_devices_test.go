@@ -6,9 +6,11 @@ package tailscale
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -16,6 +18,84 @@ import (
 	"tailscale.com/client/tailscale/v2"
 )
 
+func TestProvider_DataSourceDevices_MapsAndCount(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	devices := []tailscale.Device{
+		{Name: "web.example.ts.net", Hostname: "web", NodeID: "node-old", Created: tailscale.Time{Time: created}},
+		{Name: "web-1.example.ts.net", Hostname: "web", NodeID: "node-new", Created: tailscale.Time{Time: created.Add(time.Hour)}},
+		{Name: "db.example.ts.net", Hostname: "db", NodeID: "node-db", Created: tailscale.Time{Time: created}},
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.ResponseCode = http.StatusOK
+			testServer.ResponseBody = map[string][]tailscale.Device{"devices": devices}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `data "tailscale_devices" "all" {
+					sort_by = "name"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tailscale_devices.all", "device_count", "3"),
+					resource.TestCheckResourceAttr("data.tailscale_devices.all", "devices.0.node_id", "node-db"),
+					resource.TestCheckResourceAttr("data.tailscale_devices.all", "devices.1.node_id", "node-new"),
+					resource.TestCheckResourceAttr("data.tailscale_devices.all", "devices.2.node_id", "node-old"),
+					resource.TestCheckResourceAttr("data.tailscale_devices.all", "devices_by_node_id.%", "3"),
+					resource.TestCheckResourceAttr("data.tailscale_devices.all", "devices_by_node_id.node-old.name", "web.example.ts.net"),
+					resource.TestCheckResourceAttr("data.tailscale_devices.all", "devices_by_hostname.%", "2"),
+					resource.TestCheckResourceAttr("data.tailscale_devices.all", "devices_by_hostname.web.node_id", "node-new"),
+					resource.TestCheckResourceAttr("data.tailscale_devices.all", "devices_by_hostname.db.node_id", "node-db"),
+				),
+			},
+		},
+	})
+}
+
+func TestSortDevices(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	seen := func(d time.Duration) *tailscale.Time {
+		return &tailscale.Time{Time: base.Add(d)}
+	}
+
+	devices := []tailscale.Device{
+		{NodeID: "c", Name: "b.ts.net", Created: tailscale.Time{Time: base.Add(2 * time.Hour)}, LastSeen: nil},
+		{NodeID: "a", Name: "c.ts.net", Created: tailscale.Time{Time: base}, LastSeen: seen(time.Hour)},
+		{NodeID: "b", Name: "a.ts.net", Created: tailscale.Time{Time: base}, LastSeen: seen(-time.Hour)},
+	}
+
+	nodeIDs := func(devices []tailscale.Device) string {
+		ids := make([]string, 0, len(devices))
+		for _, d := range devices {
+			ids = append(ids, d.NodeID)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	testCases := []struct {
+		sortBy string
+		want   string
+	}{
+		{sortBy: "", want: "c,a,b"},
+		{sortBy: "name", want: "b,c,a"},
+		{sortBy: "created", want: "a,b,c"},
+		{sortBy: "last_seen", want: "b,a,c"},
+	}
+
+	for _, tc := range testCases {
+		sorted := append([]tailscale.Device(nil), devices...)
+		sortDevices(sorted, tc.sortBy)
+		if got := nodeIDs(sorted); got != tc.want {
+			t.Errorf("sortDevices(%q) = %s, want %s", tc.sortBy, got, tc.want)
+		}
+	}
+}
+
 func TestAccTailscaleDevices(t *testing.T) {
 	resourceName := "data.tailscale_devices.all_devices"
 
@@ -41,7 +121,7 @@ func TestAccTailscaleDevices(t *testing.T) {
 				Config: `data "tailscale_devices" "all_devices" {}`,
 				Check: func(s *terraform.State) error {
 					client := getAccTestClient()
-					devices, err := client.Devices().List(context.Background())
+					devices, err := client.Devices().List(context.Background(), tailscale.WithFields(tailscale.IncludeFieldsAll))
 					if err != nil {
 						return fmt.Errorf("unable to list devices: %s", err)
 					}
@@ -58,7 +138,7 @@ func TestAccTailscaleDevices(t *testing.T) {
 					// first find indexes for devices
 					deviceIndexes := make(map[string]string)
 					for k, v := range rs.Attributes {
-						if strings.HasSuffix(k, ".id") {
+						if strings.HasPrefix(k, "devices.") && strings.HasSuffix(k, ".id") {
 							idx := strings.Split(k, ".")[1]
 							deviceIndexes[idx] = v
 						}
@@ -100,7 +180,7 @@ func TestAccTailscaleDevices(t *testing.T) {
 				Config: devicesDataSources.String(),
 				Check: func(s *terraform.State) error {
 					client := getAccTestClient()
-					devices, err := client.Devices().List(context.Background())
+					devices, err := client.Devices().List(context.Background(), tailscale.WithFields(tailscale.IncludeFieldsAll))
 					if err != nil {
 						return fmt.Errorf("unable to list devices: %s", err)
 					}
@@ -150,6 +230,7 @@ func TestAccTailscaleDevices(t *testing.T) {
 				Check: func(s *terraform.State) error {
 					client := getAccTestClient()
 					devices, err := client.Devices().List(context.Background(),
+						tailscale.WithFields(tailscale.IncludeFieldsAll),
 						tailscale.WithFilter("isEphemeral", []string{"true"}),
 						tailscale.WithFilter("tags", []string{"tag:server", "tag:test"}),
 					)
@@ -169,7 +250,7 @@ func TestAccTailscaleDevices(t *testing.T) {
 					// first find indexes for devices
 					deviceIndexes := make(map[string]string)
 					for k, v := range rs.Attributes {
-						if strings.HasSuffix(k, ".id") {
+						if strings.HasPrefix(k, "devices.") && strings.HasSuffix(k, ".id") {
 							idx := strings.Split(k, ".")[1]
 							deviceIndexes[idx] = v
 						}
@@ -0,0 +1,156 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &multipleFederatedIdentitiesDataSource{}
+)
+
+// NewMultipleFederatedIdentitiesDataSource returns a new federated identities data source.
+func NewMultipleFederatedIdentitiesDataSource() datasource.DataSource {
+	return &multipleFederatedIdentitiesDataSource{}
+}
+
+type multipleFederatedIdentitiesDataSource struct {
+	DataSourceBase
+}
+
+type multipleFederatedIdentitiesDataSourceModel struct {
+	ID                  types.String             `tfsdk:"id"`
+	All                 types.Bool               `tfsdk:"all"`
+	Scopes              types.Set                `tfsdk:"scopes"`
+	Tags                types.Set                `tfsdk:"tags"`
+	FederatedIdentities []federatedIdentityModel `tfsdk:"federated_identities"`
+}
+
+// Metadata defines the data source name as it appears in Terraform configurations.
+func (d multipleFederatedIdentitiesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_federated_identities"
+}
+
+// Schema defines a schema describing what data is available in the data source response.
+func (d multipleFederatedIdentitiesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The federated_identities data source describes the federated identities in a tailnet.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"all": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to list the federated identities of all users in the tailnet, rather than only the federated identities owned by the user or OAuth client used by the provider. Requires admin permissions. Defaults to `false`.",
+			},
+			"scopes": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Filter the results to only include federated identities that are granted any of the given scopes, either directly or through a broader scope such as `all`.",
+			},
+			"tags": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Filter the results to only include federated identities that may assign all of the given tags.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"federated_identities": schema.ListNestedBlock{
+				Description: "The list of federated identities in the tailnet",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The client ID, also known as the key id.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description of the federated identity.",
+							Computed:    true,
+						},
+						"scopes": schema.SetAttribute{
+							Description: "The scopes granted to the federated identity.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"tags": schema.SetAttribute{
+							Description: "The tags that access tokens generated for the federated identity are able to assign to devices.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"audience": schema.StringAttribute{
+							Description: "The value matched against the `aud` claim from an OIDC identity token.",
+							Computed:    true,
+						},
+						"subject": schema.StringAttribute{
+							Description: "The pattern matched against the `sub` claim from an OIDC identity token.",
+							Computed:    true,
+						},
+						"issuer": schema.StringAttribute{
+							Description: "The issuer of the OIDC identity tokens accepted by the federated identity.",
+							Computed:    true,
+						},
+						"custom_claim_rules": schema.MapAttribute{
+							Description: "A map of claim names to the patterns matched against those claims in the OIDC identity token.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "The creation timestamp of the federated identity in RFC3339 format.",
+							Computed:    true,
+						},
+						"updated_at": schema.StringAttribute{
+							Description: "The updated timestamp of the federated identity in RFC3339 format.",
+							Computed:    true,
+						},
+						"user_id": schema.StringAttribute{
+							Description: "The ID of the user who created the federated identity, empty for federated identities created by other trust credentials.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read fetches the data from the Tailscale API.
+func (d multipleFederatedIdentitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data multipleFederatedIdentitiesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := keyFilter{KeyType: "federated"}
+	resp.Diagnostics.Append(data.Scopes.ElementsAs(ctx, &filter.Scopes, false)...)
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &filter.Tags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, err := listKeys(ctx, d.Client, data.All.ValueBool(), filter)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to fetch federated identities", err.Error())
+		return
+	}
+
+	data.FederatedIdentities = make([]federatedIdentityModel, len(keys))
+	for i, key := range keys {
+		// The description is never null, unlike in the resource where it is
+		// kept as configured.
+		data.FederatedIdentities[i].Description = types.StringValue("")
+		resp.Diagnostics.Append(data.FederatedIdentities[i].populateFromKey(ctx, key)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(createUUID())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
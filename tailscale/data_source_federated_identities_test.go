@@ -0,0 +1,37 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestProvider_DataSourceFederatedIdentities(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.HandleRequest = testTrustCredentials
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "tailscale_federated_identities" "policy_file" {
+						scopes = ["policy_file:read"]
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tailscale_federated_identities.policy_file", "federated_identities.#", "1"),
+					resource.TestCheckResourceAttr("data.tailscale_federated_identities.policy_file", "federated_identities.0.id", "github"),
+					resource.TestCheckResourceAttr("data.tailscale_federated_identities.policy_file", "federated_identities.0.issuer", "https://token.actions.githubusercontent.com"),
+					resource.TestCheckResourceAttr("data.tailscale_federated_identities.policy_file", "federated_identities.0.subject", "repo:example/acls:ref:refs/heads/main"),
+					resource.TestCheckResourceAttr("data.tailscale_federated_identities.policy_file", "federated_identities.0.audience", "api.tailscale.com/github"),
+					resource.TestCheckResourceAttr("data.tailscale_federated_identities.policy_file", "federated_identities.0.description", ""),
+					resource.TestCheckResourceAttr("data.tailscale_federated_identities.policy_file", "federated_identities.0.custom_claim_rules.%", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &multipleOAuthClientsDataSource{}
+)
+
+// NewMultipleOAuthClientsDataSource returns a new OAuth clients data source.
+func NewMultipleOAuthClientsDataSource() datasource.DataSource {
+	return &multipleOAuthClientsDataSource{}
+}
+
+type multipleOAuthClientsDataSource struct {
+	DataSourceBase
+}
+
+type multipleOAuthClientsDataSourceModel struct {
+	ID      types.String       `tfsdk:"id"`
+	All     types.Bool         `tfsdk:"all"`
+	Scopes  types.Set          `tfsdk:"scopes"`
+	Tags    types.Set          `tfsdk:"tags"`
+	Clients []oauthClientModel `tfsdk:"clients"`
+}
+
+// Metadata defines the data source name as it appears in Terraform configurations.
+func (d multipleOAuthClientsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oauth_clients"
+}
+
+// Schema defines a schema describing what data is available in the data source response.
+func (d multipleOAuthClientsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The oauth_clients data source describes the OAuth clients in a tailnet. The client secrets are never read.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"all": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to list the OAuth clients of all users in the tailnet, rather than only the clients owned by the user or OAuth client used by the provider. Requires admin permissions. Defaults to `false`.",
+			},
+			"scopes": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Filter the results to only include OAuth clients that are granted any of the given scopes, either directly or through a broader scope such as `all`.",
+			},
+			"tags": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Filter the results to only include OAuth clients that may assign all of the given tags.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"clients": schema.ListNestedBlock{
+				Description: "The list of OAuth clients in the tailnet",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The client ID, also known as the key id.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description of the OAuth client.",
+							Computed:    true,
+						},
+						"scopes": schema.SetAttribute{
+							Description: "The scopes granted to the OAuth client.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"tags": schema.SetAttribute{
+							Description: "The tags that access tokens generated for the OAuth client are able to assign to devices.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "The creation timestamp of the OAuth client in RFC3339 format.",
+							Computed:    true,
+						},
+						"updated_at": schema.StringAttribute{
+							Description: "The updated timestamp of the OAuth client in RFC3339 format.",
+							Computed:    true,
+						},
+						"user_id": schema.StringAttribute{
+							Description: "The ID of the user who created the OAuth client, empty for OAuth clients created by other trust credentials.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read fetches the data from the Tailscale API.
+func (d multipleOAuthClientsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data multipleOAuthClientsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := keyFilter{KeyType: "client"}
+	resp.Diagnostics.Append(data.Scopes.ElementsAs(ctx, &filter.Scopes, false)...)
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &filter.Tags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, err := listKeys(ctx, d.Client, data.All.ValueBool(), filter)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to fetch oauth clients", err.Error())
+		return
+	}
+
+	data.Clients = make([]oauthClientModel, len(keys))
+	for i, key := range keys {
+		resp.Diagnostics.Append(data.Clients[i].readFrom(ctx, key)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(createUUID())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"tailscale.com/client/tailscale/v2"
+)
+
+// testTrustCredentials handles requests to list and fetch a set of OAuth
+// clients, federated identities and auth keys.
+func testTrustCredentials(method, path string) TestResponse {
+	keys := map[string]tailscale.Key{
+		"admin":      {ID: "admin", KeyType: "client", Description: "admin", Scopes: []string{"all"}},
+		"reader":     {ID: "reader", KeyType: "client", Scopes: []string{"all:read"}},
+		"operator":   {ID: "operator", KeyType: "client", Scopes: []string{"auth_keys", "devices:core"}, Tags: []string{"tag:k8s-operator"}},
+		"github":     {ID: "github", KeyType: "federated", Scopes: []string{"policy_file"}, Issuer: "https://token.actions.githubusercontent.com", Subject: "repo:example/acls:ref:refs/heads/main", Audience: "api.tailscale.com/github"},
+		"gitlab":     {ID: "gitlab", KeyType: "federated", Scopes: []string{"devices:core:read"}, Issuer: "https://gitlab.com", Subject: "project_path:example/*"},
+		"server-key": {ID: "server-key", KeyType: "auth"},
+	}
+
+	if strings.HasSuffix(path, "/keys") {
+		var listed []tailscale.Key
+		for _, id := range []string{"admin", "reader", "operator", "github", "gitlab", "server-key"} {
+			listed = append(listed, tailscale.Key{ID: id})
+		}
+		return TestResponse{Code: http.StatusOK, Body: map[string][]tailscale.Key{"keys": listed}}
+	}
+	return TestResponse{Code: http.StatusOK, Body: keys[path[strings.LastIndex(path, "/")+1:]]}
+}
+
+func TestProvider_DataSourceOAuthClients(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.HandleRequest = testTrustCredentials
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "tailscale_oauth_clients" "all" {}
+
+					data "tailscale_oauth_clients" "auth_keys" {
+						scopes = ["auth_keys"]
+					}
+
+					data "tailscale_oauth_clients" "operator" {
+						tags = ["tag:k8s-operator"]
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tailscale_oauth_clients.all", "clients.#", "3"),
+					resource.TestCheckResourceAttr("data.tailscale_oauth_clients.all", "clients.0.id", "admin"),
+					resource.TestCheckResourceAttr("data.tailscale_oauth_clients.all", "clients.0.description", "admin"),
+					resource.TestCheckNoResourceAttr("data.tailscale_oauth_clients.all", "clients.0.key"),
+					resource.TestCheckResourceAttr("data.tailscale_oauth_clients.auth_keys", "clients.#", "2"),
+					resource.TestCheckResourceAttr("data.tailscale_oauth_clients.auth_keys", "clients.0.id", "admin"),
+					resource.TestCheckResourceAttr("data.tailscale_oauth_clients.auth_keys", "clients.1.id", "operator"),
+					resource.TestCheckResourceAttr("data.tailscale_oauth_clients.operator", "clients.#", "1"),
+					resource.TestCheckResourceAttr("data.tailscale_oauth_clients.operator", "clients.0.scopes.#", "2"),
+				),
+			},
+		},
+	})
+}
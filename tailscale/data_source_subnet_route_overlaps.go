@@ -0,0 +1,258 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"net/netip"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"tailscale.com/client/tailscale/v2"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &subnetRouteOverlapsDataSource{}
+)
+
+// NewSubnetRouteOverlapsDataSource returns a new subnet route overlaps data source.
+func NewSubnetRouteOverlapsDataSource() datasource.DataSource {
+	return &subnetRouteOverlapsDataSource{}
+}
+
+type subnetRouteOverlapsDataSource struct {
+	DataSourceBase
+}
+
+type subnetRouteOverlapsDataSourceModel struct {
+	ID                types.String              `tfsdk:"id"`
+	IncludeAdvertised types.Bool                `tfsdk:"include_advertised"`
+	Filters           []filterModel             `tfsdk:"filter"`
+	Overlaps          []subnetRouteOverlapModel `tfsdk:"overlaps"`
+}
+
+type subnetRouteOverlapModel struct {
+	Prefix            types.String `tfsdk:"prefix"`
+	OverlappingPrefix types.String `tfsdk:"overlapping_prefix"`
+	Duplicate         types.Bool   `tfsdk:"duplicate"`
+	NodeIDs           types.Set    `tfsdk:"node_ids"`
+	Enabled           types.Bool   `tfsdk:"enabled"`
+	LikelyHAPair      types.Bool   `tfsdk:"likely_ha_pair"`
+}
+
+// Metadata defines the data source name as it appears in Terraform configurations.
+func (d subnetRouteOverlapsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_subnet_route_overlaps"
+}
+
+// Schema defines a schema describing what data is available in the data source response.
+func (d subnetRouteOverlapsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The subnet_route_overlaps data source reports subnet routes that are served by more than one device in a tailnet, either as duplicate prefixes or as prefixes that overlap. Exit node routes are not considered. This is useful in `check` blocks to catch overlapping routes that were enabled by accident.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"include_advertised": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, routes that are advertised but not enabled are also considered. Defaults to `false`, in which case only enabled routes are considered.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": deviceFilterBlock("Filters the devices whose routes are considered to those whose fields match the provided values."),
+			"overlaps": schema.ListNestedBlock{
+				Description: "The overlapping routes, ordered by prefix. Each entry describes a pair of prefixes that overlap, or a single prefix that is served by several devices.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"prefix": schema.StringAttribute{
+							Computed:    true,
+							Description: "The first of the overlapping prefixes",
+						},
+						"overlapping_prefix": schema.StringAttribute{
+							Computed:    true,
+							Description: "The prefix that overlaps with `prefix`. This is the same as `prefix` for duplicate prefixes.",
+						},
+						"duplicate": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the same prefix is served by several devices",
+						},
+						"node_ids": schema.SetAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "The node IDs of the devices serving the overlapping prefixes",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the overlapping prefixes are enabled on all of the devices serving them. This is only ever false if `include_advertised` is set.",
+						},
+						"likely_ha_pair": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the devices serving the overlapping prefixes all have the same, non-empty, set of tags, which suggests that they are intended to provide high availability for the routes",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read fetches the data from the Tailscale API.
+func (d subnetRouteOverlapsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data subnetRouteOverlapsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Listing devices with all fields includes the advertised and enabled
+	// routes of every device, which saves fetching them device by device.
+	opts := append(deviceFilterOptions(ctx, data.Filters, &resp.Diagnostics), tailscale.WithFields(tailscale.IncludeFieldsAll))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	devices, err := d.Client.Devices().List(ctx, opts...)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to fetch devices", err.Error())
+		return
+	}
+
+	data.Overlaps = make([]subnetRouteOverlapModel, 0)
+	for _, overlap := range findRouteOverlaps(devices, data.IncludeAdvertised.ValueBool()) {
+		data.Overlaps = append(data.Overlaps, subnetRouteOverlapModel{
+			Prefix:            types.StringValue(overlap.Prefix),
+			OverlappingPrefix: types.StringValue(overlap.OverlappingPrefix),
+			Duplicate:         types.BoolValue(overlap.Prefix == overlap.OverlappingPrefix),
+			NodeIDs:           SetOfStringValue(ctx, overlap.NodeIDs, &resp.Diagnostics),
+			Enabled:           types.BoolValue(overlap.Enabled),
+			LikelyHAPair:      types.BoolValue(overlap.LikelyHAPair),
+		})
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(createUUID())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// routeOverlap describes a pair of overlapping prefixes served by different
+// devices. Prefix and OverlappingPrefix are equal for duplicate prefixes.
+type routeOverlap struct {
+	Prefix            string
+	OverlappingPrefix string
+	NodeIDs           []string
+	Enabled           bool
+	LikelyHAPair      bool
+}
+
+// deviceRoute is a route served by a single device.
+type deviceRoute struct {
+	device  *tailscale.Device
+	enabled bool
+}
+
+// findRouteOverlaps returns the overlapping routes served by the given devices,
+// ordered by prefix. Only enabled routes are considered, unless includeAdvertised
+// is set. Exit node routes are ignored, as they overlap with every other route.
+func findRouteOverlaps(devices []tailscale.Device, includeAdvertised bool) []routeOverlap {
+	routesByPrefix := make(map[netip.Prefix][]deviceRoute)
+	for i := range devices {
+		device := &devices[i]
+
+		add := func(route string, enabled bool) {
+			if slices.Contains(exitNodeRoutes, route) {
+				return
+			}
+			prefix, err := netip.ParsePrefix(route)
+			if err != nil {
+				return
+			}
+			prefix = prefix.Masked()
+			if slices.ContainsFunc(routesByPrefix[prefix], func(r deviceRoute) bool { return r.device == device }) {
+				return
+			}
+			routesByPrefix[prefix] = append(routesByPrefix[prefix], deviceRoute{device: device, enabled: enabled})
+		}
+
+		for _, route := range device.EnabledRoutes {
+			add(route, true)
+		}
+		if includeAdvertised {
+			for _, route := range device.AdvertisedRoutes {
+				add(route, slices.Contains(device.EnabledRoutes, route))
+			}
+		}
+	}
+
+	prefixes := slices.Collect(maps.Keys(routesByPrefix))
+	slices.SortFunc(prefixes, func(a, b netip.Prefix) int {
+		return cmp.Or(a.Addr().Compare(b.Addr()), cmp.Compare(a.Bits(), b.Bits()))
+	})
+
+	var overlaps []routeOverlap
+	for i, prefix := range prefixes {
+		for _, other := range prefixes[i:] {
+			if !prefix.Overlaps(other) {
+				continue
+			}
+
+			routes := routesByPrefix[prefix]
+			otherRoutes := routesByPrefix[other]
+			if !servedByDifferentDevices(routes, otherRoutes) {
+				continue
+			}
+
+			if prefix != other {
+				routes = append(slices.Clip(routes), otherRoutes...)
+			}
+			overlaps = append(overlaps, newRouteOverlap(prefix, other, routes))
+		}
+	}
+	return overlaps
+}
+
+// servedByDifferentDevices reports whether a route in a and a route in b are
+// served by different devices.
+func servedByDifferentDevices(a, b []deviceRoute) bool {
+	for _, ra := range a {
+		for _, rb := range b {
+			if ra.device != rb.device {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func newRouteOverlap(prefix, other netip.Prefix, routes []deviceRoute) routeOverlap {
+	overlap := routeOverlap{
+		Prefix:            prefix.String(),
+		OverlappingPrefix: other.String(),
+		Enabled:           true,
+		LikelyHAPair:      true,
+	}
+
+	var tags []string
+	for i, route := range routes {
+		overlap.NodeIDs = append(overlap.NodeIDs, route.device.NodeID)
+		overlap.Enabled = overlap.Enabled && route.enabled
+
+		deviceTags := slices.Sorted(slices.Values(route.device.Tags))
+		if i == 0 {
+			tags = deviceTags
+		}
+		if len(deviceTags) == 0 || !slices.Equal(tags, deviceTags) {
+			overlap.LikelyHAPair = false
+		}
+	}
+
+	slices.Sort(overlap.NodeIDs)
+	overlap.NodeIDs = slices.Compact(overlap.NodeIDs)
+	return overlap
+}
@@ -0,0 +1,113 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"tailscale.com/client/tailscale/v2"
+)
+
+var testRouteDevices = []tailscale.Device{
+	{
+		NodeID:           "router-a",
+		Tags:             []string{"tag:router", "tag:eu"},
+		AdvertisedRoutes: []string{"10.0.0.0/16", "0.0.0.0/0", "::/0"},
+		EnabledRoutes:    []string{"10.0.0.0/16", "0.0.0.0/0", "::/0"},
+	},
+	{
+		NodeID:           "router-b",
+		Tags:             []string{"tag:eu", "tag:router"},
+		AdvertisedRoutes: []string{"10.0.0.0/16", "0.0.0.0/0", "::/0"},
+		EnabledRoutes:    []string{"10.0.0.0/16", "0.0.0.0/0", "::/0"},
+	},
+	{
+		NodeID:           "router-c",
+		Tags:             []string{"tag:us"},
+		AdvertisedRoutes: []string{"10.0.1.0/24", "192.168.0.0/24"},
+		EnabledRoutes:    []string{"10.0.1.0/24"},
+	},
+	{
+		NodeID:           "router-d",
+		AdvertisedRoutes: []string{"192.168.0.0/24", "192.168.0.0/25"},
+		EnabledRoutes:    []string{"192.168.0.0/24", "192.168.0.0/25"},
+	},
+}
+
+func TestFindRouteOverlaps(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		includeAdvertised bool
+		want              []routeOverlap
+	}{
+		{
+			name: "enabled-routes",
+			want: []routeOverlap{
+				{Prefix: "10.0.0.0/16", OverlappingPrefix: "10.0.0.0/16", NodeIDs: []string{"router-a", "router-b"}, Enabled: true, LikelyHAPair: true},
+				{Prefix: "10.0.0.0/16", OverlappingPrefix: "10.0.1.0/24", NodeIDs: []string{"router-a", "router-b", "router-c"}, Enabled: true},
+			},
+		},
+		{
+			name:              "include-advertised",
+			includeAdvertised: true,
+			want: []routeOverlap{
+				{Prefix: "10.0.0.0/16", OverlappingPrefix: "10.0.0.0/16", NodeIDs: []string{"router-a", "router-b"}, Enabled: true, LikelyHAPair: true},
+				{Prefix: "10.0.0.0/16", OverlappingPrefix: "10.0.1.0/24", NodeIDs: []string{"router-a", "router-b", "router-c"}, Enabled: true},
+				{Prefix: "192.168.0.0/24", OverlappingPrefix: "192.168.0.0/24", NodeIDs: []string{"router-c", "router-d"}},
+				{Prefix: "192.168.0.0/24", OverlappingPrefix: "192.168.0.0/25", NodeIDs: []string{"router-c", "router-d"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findRouteOverlaps(testRouteDevices, tt.includeAdvertised)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want overlaps\n%+v\ngot\n%+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFindRouteOverlaps_NoOverlaps(t *testing.T) {
+	t.Parallel()
+
+	devices := []tailscale.Device{
+		{NodeID: "a", EnabledRoutes: []string{"10.0.0.1/24", "10.0.0.0/24"}},
+		{NodeID: "b", EnabledRoutes: []string{"10.0.1.0/24", "0.0.0.0/0", "::/0"}},
+	}
+
+	if got := findRouteOverlaps(devices, false); len(got) != 0 {
+		t.Errorf("want no overlaps, got %+v", got)
+	}
+}
+
+func TestProvider_DataSourceSubnetRouteOverlaps(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.SetResponses([]TestResponse{
+				{Code: http.StatusOK, Body: map[string][]tailscale.Device{"devices": testRouteDevices}},
+			})
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `data "tailscale_subnet_route_overlaps" "all" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tailscale_subnet_route_overlaps.all", "overlaps.#", "2"),
+					resource.TestCheckResourceAttr("data.tailscale_subnet_route_overlaps.all", "overlaps.0.duplicate", "true"),
+					resource.TestCheckResourceAttr("data.tailscale_subnet_route_overlaps.all", "overlaps.0.likely_ha_pair", "true"),
+					resource.TestCheckResourceAttr("data.tailscale_subnet_route_overlaps.all", "overlaps.1.overlapping_prefix", "10.0.1.0/24"),
+					resource.TestCheckResourceAttr("data.tailscale_subnet_route_overlaps.all", "overlaps.1.node_ids.#", "3"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"context"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &singleTailnetKeyDataSource{}
+)
+
+// NewSingleTailnetKeyDataSource returns a new single tailnet key data source.
+func NewSingleTailnetKeyDataSource() datasource.DataSource {
+	return &singleTailnetKeyDataSource{}
+}
+
+type singleTailnetKeyDataSource struct {
+	DataSourceBase
+}
+
+// Metadata defines the data source name as it appears in Terraform configurations.
+func (d singleTailnetKeyDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tailnet_key"
+}
+
+// Schema defines a schema describing what data is available in the data source response.
+func (d singleTailnetKeyDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "The ID of the key.",
+			Required:    true,
+		},
+	}
+	maps.Copy(attributes, tailnetKeySchema)
+
+	resp.Schema = schema.Schema{
+		Description: "The tailnet_key data source describes an existing key of any type in a tailnet, such as an auth key created by another workspace. The key itself is never read.",
+		Attributes:  attributes,
+	}
+}
+
+// Read fetches the data from the Tailscale API.
+func (d singleTailnetKeyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data tailnetKeyDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keyID := data.ID.ValueString()
+
+	key, err := d.Client.Keys().Get(ctx, keyID)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to fetch key", "Failed to fetch key with ID "+keyID+": "+err.Error())
+		return
+	}
+
+	data, diags := toTailnetKeyDataSourceModel(ctx, key)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"tailscale.com/client/tailscale/v2"
+)
+
+func TestProvider_DataSourceTailnetKey(t *testing.T) {
+	expires := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					testServer.SetResponses([]TestResponse{
+						{Code: http.StatusOK, Body: tailscale.Key{
+							ID:      "client1",
+							KeyType: "client",
+							Key:     "tskey-client-secret",
+							Expires: expires,
+							Tags:    []string{"tag:k8s-operator"},
+							Scopes:  []string{"devices:core", "auth_keys"},
+						}},
+					})
+				},
+				Config: `data "tailscale_tailnet_key" "operator" { id = "client1" }`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tailscale_tailnet_key.operator", "key_type", "client"),
+					resource.TestCheckResourceAttr("data.tailscale_tailnet_key.operator", "expires_at", "2026-03-01T00:00:00Z"),
+					resource.TestCheckTypeSetElemAttr("data.tailscale_tailnet_key.operator", "tags.*", "tag:k8s-operator"),
+					resource.TestCheckResourceAttr("data.tailscale_tailnet_key.operator", "scopes.#", "2"),
+					resource.TestCheckNoResourceAttr("data.tailscale_tailnet_key.operator", "key"),
+				),
+			},
+			{
+				PreConfig: func() {
+					testServer.SetResponses([]TestResponse{
+						{Code: http.StatusNotFound, Body: map[string]string{"message": "not found"}},
+					})
+				},
+				Config:      `data "tailscale_tailnet_key" "missing" { id = "missing" }`,
+				ExpectError: regexp.MustCompile(`Failed to fetch key with ID missing`),
+			},
+		},
+	})
+}
@@ -0,0 +1,219 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"tailscale.com/client/tailscale/v2"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &multipleTailnetKeysDataSource{}
+)
+
+// keyTypes are the types of keys in a tailnet.
+var keyTypes = []string{"auth", "api", "client", "federated"}
+
+// NewMultipleTailnetKeysDataSource returns a new tailnet keys data source.
+func NewMultipleTailnetKeysDataSource() datasource.DataSource {
+	return &multipleTailnetKeysDataSource{}
+}
+
+type multipleTailnetKeysDataSource struct {
+	DataSourceBase
+}
+
+type multipleTailnetKeysDataSourceModel struct {
+	ID             types.String                `tfsdk:"id"`
+	All            types.Bool                  `tfsdk:"all"`
+	KeyType        types.String                `tfsdk:"key_type"`
+	Tags           types.Set                   `tfsdk:"tags"`
+	ExpiringWithin types.String                `tfsdk:"expiring_within"`
+	Keys           []tailnetKeyDataSourceModel `tfsdk:"keys"`
+}
+
+// Metadata defines the data source name as it appears in Terraform configurations.
+func (d multipleTailnetKeysDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tailnet_keys"
+}
+
+// Schema defines a schema describing what data is available in the data source response.
+func (d multipleTailnetKeysDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	nestedKeyAttributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "The ID of the key.",
+			Computed:    true,
+		},
+	}
+	maps.Copy(nestedKeyAttributes, tailnetKeySchema)
+
+	resp.Schema = schema.Schema{
+		Description: "The tailnet_keys data source describes the keys in a tailnet, such as auth keys, API access tokens and OAuth clients. The keys themselves are never read.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"all": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to list the keys of all users in the tailnet, rather than only the keys owned by the user or OAuth client used by the provider. Requires admin permissions. Defaults to `false`.",
+			},
+			"key_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Filter the results to only include keys of a specific type. Valid values are `auth`, `api`, `client`, and `federated`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(keyTypes...),
+				},
+			},
+			"tags": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Filter the results to only include keys with all of the given tags.",
+			},
+			"expiring_within": schema.StringAttribute{
+				Optional:    true,
+				Description: "Filter the results to only include keys that expire within the given duration, e.g. `30d` or `72h`. Keys that have already expired are included.",
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"keys": schema.ListNestedBlock{
+				Description: "The list of keys in the tailnet",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: nestedKeyAttributes,
+				},
+			},
+		},
+	}
+}
+
+// Read fetches the data from the Tailscale API.
+func (d multipleTailnetKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data multipleTailnetKeysDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := keyFilter{KeyType: data.KeyType.ValueString()}
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &filter.Tags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !data.ExpiringWithin.IsNull() {
+		expiringWithin, err := parseDuration(data.ExpiringWithin.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to parse expiring_within", err.Error())
+			return
+		}
+		filter.ExpiresBefore = time.Now().Add(expiringWithin)
+	}
+
+	keys, err := listKeys(ctx, d.Client, data.All.ValueBool(), filter)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to fetch keys", err.Error())
+		return
+	}
+
+	data.Keys = make([]tailnetKeyDataSourceModel, 0, len(keys))
+	for _, key := range keys {
+		keyData, diags := toTailnetKeyDataSourceModel(ctx, key)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.Keys = append(data.Keys, keyData)
+	}
+
+	data.ID = types.StringValue(createUUID())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listKeys returns the keys in the tailnet that match the filter. If all is
+// true, the keys of all users are listed rather than only the keys owned by the
+// user or OAuth client used by the provider.
+func listKeys(ctx context.Context, client *tailscale.Client, all bool, filter keyFilter) ([]*tailscale.Key, error) {
+	listed, err := client.Keys().List(ctx, all)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*tailscale.Key, 0, len(listed))
+	for _, l := range listed {
+		// Keys are listed with only some of their fields, so each key is
+		// fetched to get all of its metadata.
+		key, err := client.Keys().Get(ctx, l.ID)
+		if err != nil {
+			// The key may have been deleted since it was listed.
+			if tailscale.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to fetch key with ID %s: %w", l.ID, err)
+		}
+
+		if filter.matches(key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// keyFilter matches keys by their type, tags and expiry. Empty criteria match
+// every key.
+type keyFilter struct {
+	KeyType string
+	// Tags must all be applied to the key, as returned by [keyTags].
+	Tags []string
+	// Scopes excludes keys that are granted none of the given scopes.
+	Scopes []string
+	// ExpiresBefore excludes keys that expire after the given time, and keys
+	// that do not expire.
+	ExpiresBefore time.Time
+}
+
+// matches reports whether the key matches all of the filter's criteria.
+func (f keyFilter) matches(key *tailscale.Key) bool {
+	if f.KeyType != "" && key.KeyType != f.KeyType {
+		return false
+	}
+	if len(missingElements(f.Tags, keyTags(key))) > 0 {
+		return false
+	}
+	if len(f.Scopes) > 0 && !slices.ContainsFunc(f.Scopes, func(scope string) bool { return scopeGranted(key.Scopes, scope) }) {
+		return false
+	}
+	if !f.ExpiresBefore.IsZero() && (key.Expires.IsZero() || key.Expires.After(f.ExpiresBefore)) {
+		return false
+	}
+	return true
+}
+
+// scopeGranted reports whether the granted scopes include the given scope,
+// either directly or through a broader scope. The "all" scope includes every
+// scope, and a scope without the ":read" suffix includes its read-only
+// counterpart.
+func scopeGranted(granted []string, scope string) bool {
+	readScope, readOnly := strings.CutSuffix(scope, ":read")
+	for _, g := range granted {
+		switch {
+		case g == scope, g == "all":
+			return true
+		case readOnly && (g == readScope || g == "all:read"):
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"tailscale.com/client/tailscale/v2"
+)
+
+func testTailnetKeys() map[string]tailscale.Key {
+	reusable := tailscale.Key{ID: "reusable", KeyType: "auth", Expires: time.Now().Add(10 * 24 * time.Hour)}
+	reusable.Capabilities.Devices.Create.Reusable = true
+	reusable.Capabilities.Devices.Create.Tags = []string{"tag:server", "tag:prod"}
+
+	return map[string]tailscale.Key{
+		"reusable": reusable,
+		"api":      {ID: "api", KeyType: "api", Expires: time.Now().Add(60 * 24 * time.Hour)},
+		"client":   {ID: "client", KeyType: "client", Tags: []string{"tag:server"}, Scopes: []string{"devices:core"}},
+	}
+}
+
+func TestKeyFilterMatches(t *testing.T) {
+	t.Parallel()
+
+	keys := testTailnetKeys()
+	in30Days := time.Now().Add(30 * 24 * time.Hour)
+
+	tests := []struct {
+		name   string
+		filter keyFilter
+		want   []string
+	}{
+		{name: "empty", filter: keyFilter{}, want: []string{"api", "client", "reusable"}},
+		{name: "key-type", filter: keyFilter{KeyType: "auth"}, want: []string{"reusable"}},
+		{name: "tags", filter: keyFilter{Tags: []string{"tag:server"}}, want: []string{"client", "reusable"}},
+		{name: "expires-before", filter: keyFilter{ExpiresBefore: in30Days}, want: []string{"reusable"}},
+		{name: "scopes", filter: keyFilter{Scopes: []string{"devices:core:read", "policy_file"}}, want: []string{"client"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, id := range []string{"api", "client", "reusable"} {
+				key := keys[id]
+				if tt.filter.matches(&key) {
+					got = append(got, id)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestScopeGranted(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		granted []string
+		scope   string
+		want    bool
+	}{
+		{name: "exact", granted: []string{"auth_keys"}, scope: "auth_keys", want: true},
+		{name: "read-from-write", granted: []string{"auth_keys"}, scope: "auth_keys:read", want: true},
+		{name: "write-from-read", granted: []string{"auth_keys:read"}, scope: "auth_keys", want: false},
+		{name: "all", granted: []string{"all"}, scope: "policy_file", want: true},
+		{name: "all-read", granted: []string{"all:read"}, scope: "policy_file:read", want: true},
+		{name: "all-read-write", granted: []string{"all:read"}, scope: "policy_file", want: false},
+		{name: "other", granted: []string{"devices:core"}, scope: "policy_file", want: false},
+		{name: "none", granted: nil, scope: "policy_file", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scopeGranted(tt.granted, tt.scope); got != tt.want {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProvider_DataSourceTailnetKeys(t *testing.T) {
+	keys := testTailnetKeys()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.HandleRequest = func(method, path string) TestResponse {
+				if strings.HasSuffix(path, "/keys") {
+					return TestResponse{Code: http.StatusOK, Body: map[string][]tailscale.Key{"keys": {
+						{ID: "reusable"}, {ID: "api"}, {ID: "client"},
+					}}}
+				}
+				return TestResponse{Code: http.StatusOK, Body: keys[path[strings.LastIndex(path, "/")+1:]]}
+			}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "tailscale_tailnet_keys" "expiring" {
+						tags            = ["tag:server"]
+						expiring_within = "30d"
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tailscale_tailnet_keys.expiring", "keys.#", "1"),
+					resource.TestCheckResourceAttr("data.tailscale_tailnet_keys.expiring", "keys.0.id", "reusable"),
+					resource.TestCheckResourceAttr("data.tailscale_tailnet_keys.expiring", "keys.0.key_type", "auth"),
+					resource.TestCheckResourceAttr("data.tailscale_tailnet_keys.expiring", "keys.0.reusable", "true"),
+					resource.TestCheckResourceAttr("data.tailscale_tailnet_keys.expiring", "keys.0.tags.#", "2"),
+				),
+			},
+		},
+	})
+}
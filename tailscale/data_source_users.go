@@ -6,10 +6,15 @@ package tailscale
 import (
 	"context"
 	"maps"
+	"regexp"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"tailscale.com/client/tailscale/v2"
@@ -29,10 +34,23 @@ type multipleUsersDataSource struct {
 }
 
 type multipleUsersDataSourceModel struct {
-	ID    types.String          `tfsdk:"id"`
-	Type  types.String          `tfsdk:"type"`
-	Role  types.String          `tfsdk:"role"`
-	Users []userDataSourceModel `tfsdk:"users"`
+	ID                    types.String           `tfsdk:"id"`
+	Type                  types.String           `tfsdk:"type"`
+	Role                  types.String           `tfsdk:"role"`
+	Status                types.String           `tfsdk:"status"`
+	LoginNameRegex        types.String           `tfsdk:"login_name_regex"`
+	LastSeenOlderThanDays types.Int64            `tfsdk:"last_seen_older_than_days"`
+	LastSeenWithinDays    types.Int64            `tfsdk:"last_seen_within_days"`
+	CurrentlyConnected    types.Bool             `tfsdk:"currently_connected"`
+	IncludeDevices        types.Bool             `tfsdk:"include_devices"`
+	Users                 []userWithDevicesModel `tfsdk:"users"`
+}
+
+// userWithDevicesModel is a user listed by the users data source, along with
+// the devices owned by the user if include_devices is set.
+type userWithDevicesModel struct {
+	userDataSourceModel
+	Devices types.List `tfsdk:"devices"`
 }
 
 // Metadata defines the data source name as it appears in Terraform configurations.
@@ -51,6 +69,11 @@ func (d multipleUsersDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 			Description: "The emailish login name of the user.",
 			Computed:    true,
 		},
+		"devices": schema.ListAttribute{
+			Description: "The devices owned by the user. Only set if include_devices is true.",
+			Computed:    true,
+			ElementType: deviceObjectType(),
+		},
 	}
 	maps.Copy(nestedUserAttributes, userSchema)
 
@@ -73,18 +96,52 @@ func (d multipleUsersDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 			"role": schema.StringAttribute{
 				Optional:    true,
 				Description: "Filter the results to only include users with a specific role. Valid values are `owner`, `member`, `admin`, `it-admin`, `network-admin`, `billing-admin`, and `auditor`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(userRoles...),
+				},
+			},
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Filter the results to only include users with a specific status. Valid values are `active`, `idle`, `suspended`, `needs-approval`, and `over-billing-limit`.",
 				Validators: []validator.String{
 					stringvalidator.OneOf(
-						string(tailscale.UserRoleOwner),
-						string(tailscale.UserRoleMember),
-						string(tailscale.UserRoleAdmin),
-						string(tailscale.UserRoleITAdmin),
-						string(tailscale.UserRoleNetworkAdmin),
-						string(tailscale.UserRoleBillingAdmin),
-						string(tailscale.UserRoleAuditor),
+						string(tailscale.UserStatusActive),
+						string(tailscale.UserStatusIdle),
+						string(tailscale.UserStatusSuspended),
+						string(tailscale.UserStatusNeedsApproval),
+						string(tailscale.UserStatusOverBillingLimit),
 					),
 				},
 			},
+			"login_name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Filter the results to only include users whose login name matches the given regular expression, e.g. `@contractor\\.com$`.",
+				Validators: []validator.String{
+					regexpValidator{},
+				},
+			},
+			"last_seen_older_than_days": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Filter the results to only include users who have not been seen for at least this many days. Users who are currently connected are never included.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"last_seen_within_days": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Filter the results to only include users who have been seen within this many days. Users who are currently connected are always included.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"currently_connected": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Filter the results to only include users who have (or, if false, do not have) a device currently connected to the control server.",
+			},
+			"include_devices": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to list the devices owned by each user in the devices attribute of the user. Defaults to `false`.",
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"users": schema.ListNestedBlock{
@@ -115,18 +172,123 @@ func (d multipleUsersDataSource) Read(ctx context.Context, req datasource.ReadRe
 		userRole = new(tailscale.UserRole(data.Role.ValueString()))
 	}
 
+	filter := userFilter{Status: tailscale.UserStatus(data.Status.ValueString())}
+	if loginNameRegex := data.LoginNameRegex.ValueString(); loginNameRegex != "" {
+		re, err := regexp.Compile(loginNameRegex)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to parse login_name_regex", err.Error())
+			return
+		}
+		filter.LoginNameRegexp = re
+	}
+	now := time.Now()
+	if !data.LastSeenOlderThanDays.IsNull() {
+		filter.LastSeenBefore = now.Add(-time.Duration(data.LastSeenOlderThanDays.ValueInt64()) * 24 * time.Hour)
+	}
+	if !data.LastSeenWithinDays.IsNull() {
+		filter.LastSeenAfter = now.Add(-time.Duration(data.LastSeenWithinDays.ValueInt64()) * 24 * time.Hour)
+	}
+	if !data.CurrentlyConnected.IsNull() {
+		filter.CurrentlyConnected = new(data.CurrentlyConnected.ValueBool())
+	}
+
 	users, err := d.Client.Users().List(ctx, userType, userRole)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to fetch users", err.Error())
 		return
 	}
 
-	data.Users = make([]userDataSourceModel, 0, len(users))
+	var devicesByUser map[string][]attr.Value
+	if data.IncludeDevices.ValueBool() {
+		devicesByUser = d.listDevicesByUser(ctx, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data.Users = make([]userWithDevicesModel, 0, len(users))
 	for _, u := range users {
-		userData := toUserDataSourceModel(&u)
+		if !filter.matches(&u) {
+			continue
+		}
+
+		userData := userWithDevicesModel{
+			userDataSourceModel: toUserDataSourceModel(&u),
+			Devices:             types.ListNull(deviceObjectType()),
+		}
+		if devicesByUser != nil {
+			var diags diag.Diagnostics
+			userData.Devices, diags = types.ListValue(deviceObjectType(), append([]attr.Value{}, devicesByUser[u.LoginName]...))
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
 		data.Users = append(data.Users, userData)
 	}
 
 	data.ID = types.StringValue(createUUID())
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// listDevicesByUser lists all devices in the tailnet, grouped by the login
+// name of the user who owns them.
+func (d multipleUsersDataSource) listDevicesByUser(ctx context.Context, diags *diag.Diagnostics) map[string][]attr.Value {
+	// Routes, client connectivity and posture identity are only returned
+	// when requesting all fields.
+	devices, err := d.Client.Devices().List(ctx, tailscale.WithFields(tailscale.IncludeFieldsAll))
+	if err != nil {
+		diags.AddError("Failed to fetch devices", err.Error())
+		return nil
+	}
+
+	devicesByUser := make(map[string][]attr.Value)
+	for _, dev := range devices {
+		deviceModel, diagnostics := toDeviceDataSourceModel(ctx, &dev)
+		diags.Append(diagnostics...)
+		if diags.HasError() {
+			return nil
+		}
+
+		deviceObject, diagnostics := types.ObjectValueFrom(ctx, deviceObjectType().AttrTypes, deviceModel)
+		diags.Append(diagnostics...)
+		if diags.HasError() {
+			return nil
+		}
+		devicesByUser[dev.User] = append(devicesByUser[dev.User], deviceObject)
+	}
+	return devicesByUser
+}
+
+// userFilter matches users by their status, login name and when they were last
+// seen. Empty criteria match every user.
+type userFilter struct {
+	Status          tailscale.UserStatus
+	LoginNameRegexp *regexp.Regexp
+	// LastSeenBefore and LastSeenAfter bound the time at which the user was
+	// last seen. Users who are currently connected are considered to be seen
+	// now.
+	LastSeenBefore     time.Time
+	LastSeenAfter      time.Time
+	CurrentlyConnected *bool
+}
+
+// matches reports whether the user matches all of the filter's criteria.
+func (f userFilter) matches(user *tailscale.User) bool {
+	if f.Status != "" && user.Status != f.Status {
+		return false
+	}
+	if f.LoginNameRegexp != nil && !f.LoginNameRegexp.MatchString(user.LoginName) {
+		return false
+	}
+	if f.CurrentlyConnected != nil && user.CurrentlyConnected != *f.CurrentlyConnected {
+		return false
+	}
+	if !f.LastSeenBefore.IsZero() && (user.CurrentlyConnected || !user.LastSeen.Before(f.LastSeenBefore)) {
+		return false
+	}
+	if !f.LastSeenAfter.IsZero() && !user.CurrentlyConnected && !user.LastSeen.After(f.LastSeenAfter) {
+		return false
+	}
+	return true
+}
@@ -6,6 +6,8 @@ package tailscale
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -148,3 +150,74 @@ func userToMap(user *tailscale.User) map[string]any {
 		"currently_connected": user.CurrentlyConnected,
 	}
 }
+
+func TestUserFilterMatches(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	daysAgo := func(days int) time.Time { return now.Add(-time.Duration(days) * 24 * time.Hour) }
+
+	contractor := &tailscale.User{LoginName: "jo@contractor.com", Status: tailscale.UserStatusIdle, LastSeen: daysAgo(90)}
+	connected := &tailscale.User{LoginName: "sam@example.com", Status: tailscale.UserStatusActive, LastSeen: daysAgo(90), CurrentlyConnected: true}
+
+	tests := []struct {
+		name   string
+		filter userFilter
+		user   *tailscale.User
+		want   bool
+	}{
+		{name: "empty", filter: userFilter{}, user: contractor, want: true},
+		{name: "status", filter: userFilter{Status: tailscale.UserStatusSuspended}, user: contractor, want: false},
+		{name: "login-name-regex", filter: userFilter{LoginNameRegexp: regexp.MustCompile(`@contractor\.com$`)}, user: contractor, want: true},
+		{name: "login-name-regex-mismatch", filter: userFilter{LoginNameRegexp: regexp.MustCompile(`@contractor\.com$`)}, user: connected, want: false},
+		{name: "last-seen-before", filter: userFilter{LastSeenBefore: daysAgo(60)}, user: contractor, want: true},
+		{name: "last-seen-before-connected", filter: userFilter{LastSeenBefore: daysAgo(60)}, user: connected, want: false},
+		{name: "last-seen-after", filter: userFilter{LastSeenAfter: daysAgo(60)}, user: contractor, want: false},
+		{name: "last-seen-after-connected", filter: userFilter{LastSeenAfter: daysAgo(60)}, user: connected, want: true},
+		{name: "currently-connected", filter: userFilter{CurrentlyConnected: new(false)}, user: connected, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.matches(tt.user); got != tt.want {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProvider_DataSourceUsers_IncludeDevices(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.HandleRequest = func(method, path string) TestResponse {
+				if strings.HasSuffix(path, "/devices") {
+					return TestResponse{Code: http.StatusOK, Body: map[string][]tailscale.Device{"devices": {
+						{NodeID: "node1", Hostname: "laptop", User: "jo@contractor.com"},
+						{NodeID: "node2", Hostname: "phone", User: "sam@example.com"},
+					}}}
+				}
+				return TestResponse{Code: http.StatusOK, Body: map[string][]tailscale.User{"users": {
+					{ID: "user1", LoginName: "jo@contractor.com", Status: tailscale.UserStatusIdle},
+					{ID: "user2", LoginName: "sam@example.com", Status: tailscale.UserStatusActive},
+				}}}
+			}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "tailscale_users" "contractors" {
+						login_name_regex = "@contractor\\.com$"
+						include_devices  = true
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tailscale_users.contractors", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.tailscale_users.contractors", "users.0.id", "user1"),
+					resource.TestCheckResourceAttr("data.tailscale_users.contractors", "users.0.devices.#", "1"),
+					resource.TestCheckResourceAttr("data.tailscale_users.contractors", "users.0.devices.0.node_id", "node1"),
+				),
+			},
+		},
+	})
+}
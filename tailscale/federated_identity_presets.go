@@ -0,0 +1,308 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// federatedIdentityPresetModel configures the issuer, subject and custom claim
+// rules of a federated identity for a well-known identity provider. Exactly
+// one of the presets is set.
+type federatedIdentityPresetModel struct {
+	GitHubActions  *githubActionsPresetModel  `tfsdk:"github_actions"`
+	GitLabCI       *gitlabCIPresetModel       `tfsdk:"gitlab_ci"`
+	TerraformCloud *terraformCloudPresetModel `tfsdk:"terraform_cloud"`
+	Buildkite      *buildkitePresetModel      `tfsdk:"buildkite"`
+	Kubernetes     *kubernetesPresetModel     `tfsdk:"kubernetes"`
+	GCP            *gcpPresetModel            `tfsdk:"gcp"`
+}
+
+type githubActionsPresetModel struct {
+	Repository  types.String `tfsdk:"repository"`
+	Ref         types.String `tfsdk:"ref"`
+	Environment types.String `tfsdk:"environment"`
+}
+
+type gitlabCIPresetModel struct {
+	URL         types.String `tfsdk:"url"`
+	ProjectPath types.String `tfsdk:"project_path"`
+	RefType     types.String `tfsdk:"ref_type"`
+	Ref         types.String `tfsdk:"ref"`
+	Environment types.String `tfsdk:"environment"`
+}
+
+type terraformCloudPresetModel struct {
+	Hostname     types.String `tfsdk:"hostname"`
+	Organization types.String `tfsdk:"organization"`
+	Project      types.String `tfsdk:"project"`
+	Workspace    types.String `tfsdk:"workspace"`
+	RunPhase     types.String `tfsdk:"run_phase"`
+}
+
+type buildkitePresetModel struct {
+	Organization types.String `tfsdk:"organization"`
+	Pipeline     types.String `tfsdk:"pipeline"`
+	Ref          types.String `tfsdk:"ref"`
+}
+
+type kubernetesPresetModel struct {
+	Issuer         types.String `tfsdk:"issuer"`
+	Namespace      types.String `tfsdk:"namespace"`
+	ServiceAccount types.String `tfsdk:"service_account"`
+}
+
+type gcpPresetModel struct {
+	ServiceAccountID types.String `tfsdk:"service_account_id"`
+	Email            types.String `tfsdk:"email"`
+}
+
+// federatedIdentityMatch is what a federated identity matches OIDC identity
+// tokens against.
+type federatedIdentityMatch struct {
+	Issuer           string
+	Subject          string
+	CustomClaimRules map[string]string
+}
+
+// values returns the configured values of the preset that is set.
+func (p federatedIdentityPresetModel) values() []types.String {
+	switch {
+	case p.GitHubActions != nil:
+		g := p.GitHubActions
+		return []types.String{g.Repository, g.Ref, g.Environment}
+	case p.GitLabCI != nil:
+		g := p.GitLabCI
+		return []types.String{g.URL, g.ProjectPath, g.RefType, g.Ref, g.Environment}
+	case p.TerraformCloud != nil:
+		t := p.TerraformCloud
+		return []types.String{t.Hostname, t.Organization, t.Project, t.Workspace, t.RunPhase}
+	case p.Buildkite != nil:
+		b := p.Buildkite
+		return []types.String{b.Organization, b.Pipeline, b.Ref}
+	case p.Kubernetes != nil:
+		k := p.Kubernetes
+		return []types.String{k.Issuer, k.Namespace, k.ServiceAccount}
+	case p.GCP != nil:
+		g := p.GCP
+		return []types.String{g.ServiceAccountID, g.Email}
+	}
+	return nil
+}
+
+// count returns the number of presets that are set.
+func (p federatedIdentityPresetModel) count() int {
+	n := 0
+	for _, set := range []bool{p.GitHubActions != nil, p.GitLabCI != nil, p.TerraformCloud != nil, p.Buildkite != nil, p.Kubernetes != nil, p.GCP != nil} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// resolve returns the issuer, subject and custom claim rules for the preset.
+// It returns false if no preset is set or any of its values are not yet known.
+func (p federatedIdentityPresetModel) resolve() (federatedIdentityMatch, bool) {
+	values := p.values()
+	if values == nil || slices.ContainsFunc(values, types.String.IsUnknown) {
+		return federatedIdentityMatch{}, false
+	}
+
+	m := federatedIdentityMatch{CustomClaimRules: map[string]string{}}
+	switch {
+	case p.GitHubActions != nil:
+		g := p.GitHubActions
+		m.Issuer = "https://token.actions.githubusercontent.com"
+		// The subject of tokens for jobs that reference an environment
+		// contains the environment rather than the ref.
+		switch {
+		case !g.Environment.IsNull():
+			m.Subject = fmt.Sprintf("repo:%s:environment:%s", g.Repository.ValueString(), g.Environment.ValueString())
+			if !g.Ref.IsNull() {
+				m.CustomClaimRules["ref"] = g.Ref.ValueString()
+			}
+		case !g.Ref.IsNull():
+			m.Subject = fmt.Sprintf("repo:%s:ref:%s", g.Repository.ValueString(), g.Ref.ValueString())
+		default:
+			m.Subject = fmt.Sprintf("repo:%s:*", g.Repository.ValueString())
+		}
+	case p.GitLabCI != nil:
+		g := p.GitLabCI
+		m.Issuer = strings.TrimSuffix(valueOr(g.URL, "https://gitlab.com"), "/")
+		m.Subject = fmt.Sprintf("project_path:%s:ref_type:%s:ref:%s", g.ProjectPath.ValueString(), valueOr(g.RefType, "*"), valueOr(g.Ref, "*"))
+		if !g.Environment.IsNull() {
+			m.CustomClaimRules["environment"] = g.Environment.ValueString()
+		}
+	case p.TerraformCloud != nil:
+		t := p.TerraformCloud
+		m.Issuer = "https://" + valueOr(t.Hostname, "app.terraform.io")
+		m.Subject = fmt.Sprintf("organization:%s:project:%s:workspace:%s:run_phase:%s", t.Organization.ValueString(), valueOr(t.Project, "*"), valueOr(t.Workspace, "*"), valueOr(t.RunPhase, "*"))
+	case p.Buildkite != nil:
+		b := p.Buildkite
+		m.Issuer = "https://agent.buildkite.com"
+		m.Subject = fmt.Sprintf("organization:%s:pipeline:%s:ref:%s:commit:*:step:*", b.Organization.ValueString(), b.Pipeline.ValueString(), valueOr(b.Ref, "*"))
+	case p.Kubernetes != nil:
+		k := p.Kubernetes
+		// The issuer must match the iss claim exactly, which may have a
+		// trailing slash.
+		m.Issuer = k.Issuer.ValueString()
+		m.Subject = fmt.Sprintf("system:serviceaccount:%s:%s", k.Namespace.ValueString(), k.ServiceAccount.ValueString())
+	case p.GCP != nil:
+		g := p.GCP
+		m.Issuer = "https://accounts.google.com"
+		m.Subject = g.ServiceAccountID.ValueString()
+		if !g.Email.IsNull() {
+			m.CustomClaimRules["email"] = g.Email.ValueString()
+		}
+	}
+	return m, true
+}
+
+// valueOr returns the value of v, or def if v is null.
+func valueOr(v types.String, def string) string {
+	if v.IsNull() {
+		return def
+	}
+	return v.ValueString()
+}
+
+// withCustomClaimRules returns the match with the given custom claim rules
+// added to those of the preset, taking precedence over them.
+func (m federatedIdentityMatch) withCustomClaimRules(rules map[string]string) federatedIdentityMatch {
+	merged := maps.Clone(m.CustomClaimRules)
+	maps.Copy(merged, rules)
+	m.CustomClaimRules = merged
+	return m
+}
+
+// federatedIdentityPresetBlock returns the schema of the preset block of the
+// federated identity resource.
+func federatedIdentityPresetBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: "Computes the issuer, subject and custom claim rules for a well-known identity provider. Exactly one of the nested blocks must be set. Custom claim rules that are configured are added to those of the preset. Conflicts with `issuer` and `subject`.",
+		Blocks: map[string]schema.Block{
+			"github_actions": schema.SingleNestedBlock{
+				Description: "Matches tokens issued to GitHub Actions workflows. See https://docs.github.com/en/actions/security-for-github-actions/security-hardening-your-deployments/about-security-hardening-with-openid-connect for more information.",
+				Attributes: map[string]schema.Attribute{
+					"repository": schema.StringAttribute{
+						Description: "The repository the workflow runs in, as `owner/name`.",
+						Required:    true,
+					},
+					"ref": schema.StringAttribute{
+						Description: "The Git ref the workflow runs for, e.g. `refs/heads/main`. Patterns can include `*` characters. If not set, tokens for any ref are matched.",
+						Optional:    true,
+					},
+					"environment": schema.StringAttribute{
+						Description: "The deployment environment of the job. If set, the subject matches the environment, and `ref` is matched as a custom claim rule instead.",
+						Optional:    true,
+					},
+				},
+			},
+			"gitlab_ci": schema.SingleNestedBlock{
+				Description: "Matches tokens issued to GitLab CI/CD jobs. See https://docs.gitlab.com/ci/secrets/id_token_authentication/ for more information.",
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						Description: "The URL of the GitLab instance. Defaults to `https://gitlab.com`.",
+						Optional:    true,
+					},
+					"project_path": schema.StringAttribute{
+						Description: "The path of the project the job runs in, e.g. `group/project`.",
+						Required:    true,
+					},
+					"ref_type": schema.StringAttribute{
+						Description: "The type of ref the job runs for, `branch` or `tag`. If not set, tokens for any type of ref are matched.",
+						Optional:    true,
+					},
+					"ref": schema.StringAttribute{
+						Description: "The branch or tag the job runs for, e.g. `main`. Patterns can include `*` characters. If not set, tokens for any ref are matched.",
+						Optional:    true,
+					},
+					"environment": schema.StringAttribute{
+						Description: "The environment of the job, matched as a custom claim rule.",
+						Optional:    true,
+					},
+				},
+			},
+			"terraform_cloud": schema.SingleNestedBlock{
+				Description: "Matches tokens issued to HCP Terraform or Terraform Enterprise runs. See https://developer.hashicorp.com/terraform/cloud-docs/workspaces/dynamic-provider-credentials/workload-identity-tokens for more information.",
+				Attributes: map[string]schema.Attribute{
+					"hostname": schema.StringAttribute{
+						Description: "The hostname of the Terraform Enterprise instance. Defaults to `app.terraform.io`.",
+						Optional:    true,
+					},
+					"organization": schema.StringAttribute{
+						Description: "The name of the organization.",
+						Required:    true,
+					},
+					"project": schema.StringAttribute{
+						Description: "The name of the project. If not set, runs in any project are matched.",
+						Optional:    true,
+					},
+					"workspace": schema.StringAttribute{
+						Description: "The name of the workspace. If not set, runs in any workspace are matched.",
+						Optional:    true,
+					},
+					"run_phase": schema.StringAttribute{
+						Description: "The phase of the run, `plan` or `apply`. If not set, both phases are matched.",
+						Optional:    true,
+					},
+				},
+			},
+			"buildkite": schema.SingleNestedBlock{
+				Description: "Matches tokens issued to Buildkite jobs. See https://buildkite.com/docs/agent/v3/cli-oidc for more information.",
+				Attributes: map[string]schema.Attribute{
+					"organization": schema.StringAttribute{
+						Description: "The slug of the organization.",
+						Required:    true,
+					},
+					"pipeline": schema.StringAttribute{
+						Description: "The slug of the pipeline.",
+						Required:    true,
+					},
+					"ref": schema.StringAttribute{
+						Description: "The Git ref the build runs for, e.g. `refs/heads/main`. Patterns can include `*` characters. If not set, builds for any ref are matched.",
+						Optional:    true,
+					},
+				},
+			},
+			"kubernetes": schema.SingleNestedBlock{
+				Description: "Matches service account tokens issued by a Kubernetes cluster. The cluster's service account issuer must be publicly reachable.",
+				Attributes: map[string]schema.Attribute{
+					"issuer": schema.StringAttribute{
+						Description: "The service account issuer of the cluster, exactly as it appears in the `iss` claim of its tokens.",
+						Required:    true,
+					},
+					"namespace": schema.StringAttribute{
+						Description: "The namespace of the service account.",
+						Required:    true,
+					},
+					"service_account": schema.StringAttribute{
+						Description: "The name of the service account.",
+						Required:    true,
+					},
+				},
+			},
+			"gcp": schema.SingleNestedBlock{
+				Description: "Matches ID tokens issued by Google Cloud to a service account.",
+				Attributes: map[string]schema.Attribute{
+					"service_account_id": schema.StringAttribute{
+						Description: "The unique numeric ID of the service account.",
+						Required:    true,
+					},
+					"email": schema.StringAttribute{
+						Description: "The email address of the service account, matched as a custom claim rule.",
+						Optional:    true,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFederatedIdentityPresetResolve(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		preset federatedIdentityPresetModel
+		want   federatedIdentityMatch
+	}{
+		{
+			name: "github-actions-repository",
+			preset: federatedIdentityPresetModel{GitHubActions: &githubActionsPresetModel{
+				Repository: types.StringValue("example/infra"),
+			}},
+			want: federatedIdentityMatch{
+				Issuer:  "https://token.actions.githubusercontent.com",
+				Subject: "repo:example/infra:*",
+			},
+		},
+		{
+			name: "github-actions-ref",
+			preset: federatedIdentityPresetModel{GitHubActions: &githubActionsPresetModel{
+				Repository: types.StringValue("example/infra"),
+				Ref:        types.StringValue("refs/heads/main"),
+			}},
+			want: federatedIdentityMatch{
+				Issuer:  "https://token.actions.githubusercontent.com",
+				Subject: "repo:example/infra:ref:refs/heads/main",
+			},
+		},
+		{
+			name: "github-actions-environment",
+			preset: federatedIdentityPresetModel{GitHubActions: &githubActionsPresetModel{
+				Repository:  types.StringValue("example/infra"),
+				Ref:         types.StringValue("refs/heads/main"),
+				Environment: types.StringValue("production"),
+			}},
+			want: federatedIdentityMatch{
+				Issuer:           "https://token.actions.githubusercontent.com",
+				Subject:          "repo:example/infra:environment:production",
+				CustomClaimRules: map[string]string{"ref": "refs/heads/main"},
+			},
+		},
+		{
+			name: "gitlab-ci",
+			preset: federatedIdentityPresetModel{GitLabCI: &gitlabCIPresetModel{
+				URL:         types.StringValue("https://gitlab.example.com/"),
+				ProjectPath: types.StringValue("group/project"),
+				RefType:     types.StringValue("branch"),
+				Environment: types.StringValue("production"),
+			}},
+			want: federatedIdentityMatch{
+				Issuer:           "https://gitlab.example.com",
+				Subject:          "project_path:group/project:ref_type:branch:ref:*",
+				CustomClaimRules: map[string]string{"environment": "production"},
+			},
+		},
+		{
+			name: "terraform-cloud",
+			preset: federatedIdentityPresetModel{TerraformCloud: &terraformCloudPresetModel{
+				Organization: types.StringValue("example"),
+				Workspace:    types.StringValue("network"),
+				RunPhase:     types.StringValue("apply"),
+			}},
+			want: federatedIdentityMatch{
+				Issuer:  "https://app.terraform.io",
+				Subject: "organization:example:project:*:workspace:network:run_phase:apply",
+			},
+		},
+		{
+			name: "buildkite",
+			preset: federatedIdentityPresetModel{Buildkite: &buildkitePresetModel{
+				Organization: types.StringValue("example"),
+				Pipeline:     types.StringValue("deploy"),
+			}},
+			want: federatedIdentityMatch{
+				Issuer:  "https://agent.buildkite.com",
+				Subject: "organization:example:pipeline:deploy:ref:*:commit:*:step:*",
+			},
+		},
+		{
+			name: "kubernetes",
+			preset: federatedIdentityPresetModel{Kubernetes: &kubernetesPresetModel{
+				Issuer:         types.StringValue("https://oidc.eks.us-east-1.amazonaws.com/id/EXAMPLE"),
+				Namespace:      types.StringValue("tailscale"),
+				ServiceAccount: types.StringValue("operator"),
+			}},
+			want: federatedIdentityMatch{
+				Issuer:  "https://oidc.eks.us-east-1.amazonaws.com/id/EXAMPLE",
+				Subject: "system:serviceaccount:tailscale:operator",
+			},
+		},
+		{
+			name: "kubernetes-trailing-slash",
+			preset: federatedIdentityPresetModel{Kubernetes: &kubernetesPresetModel{
+				Issuer:         types.StringValue("https://kubernetes.example.com/"),
+				Namespace:      types.StringValue("tailscale"),
+				ServiceAccount: types.StringValue("operator"),
+			}},
+			want: federatedIdentityMatch{
+				Issuer:  "https://kubernetes.example.com/",
+				Subject: "system:serviceaccount:tailscale:operator",
+			},
+		},
+		{
+			name: "gcp",
+			preset: federatedIdentityPresetModel{GCP: &gcpPresetModel{
+				ServiceAccountID: types.StringValue("123456789"),
+				Email:            types.StringValue("deployer@example.iam.gserviceaccount.com"),
+			}},
+			want: federatedIdentityMatch{
+				Issuer:           "https://accounts.google.com",
+				Subject:          "123456789",
+				CustomClaimRules: map[string]string{"email": "deployer@example.iam.gserviceaccount.com"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.preset.resolve()
+			if !ok {
+				t.Fatal("want preset to be resolved")
+			}
+			if tt.want.CustomClaimRules == nil {
+				tt.want.CustomClaimRules = map[string]string{}
+			}
+			if err := assertEqual(tt.want, got, "wrong match"); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestFederatedIdentityPresetResolveUnknown(t *testing.T) {
+	t.Parallel()
+
+	preset := federatedIdentityPresetModel{GitHubActions: &githubActionsPresetModel{
+		Repository: types.StringUnknown(),
+	}}
+	if _, ok := preset.resolve(); ok {
+		t.Error("want preset with unknown values not to be resolved")
+	}
+
+	if _, ok := (federatedIdentityPresetModel{}).resolve(); ok {
+		t.Error("want empty preset not to be resolved")
+	}
+}
+
+func TestFederatedIdentityMatchWithCustomClaimRules(t *testing.T) {
+	t.Parallel()
+
+	match := federatedIdentityMatch{CustomClaimRules: map[string]string{"ref": "refs/heads/main"}}
+	got := match.withCustomClaimRules(map[string]string{"ref": "refs/tags/*", "actor": "octocat"})
+
+	want := map[string]string{"ref": "refs/tags/*", "actor": "octocat"}
+	if err := assertEqual(want, got.CustomClaimRules, "wrong custom claim rules"); err != nil {
+		t.Error(err)
+	}
+	if match.CustomClaimRules["ref"] != "refs/heads/main" {
+		t.Error("want the custom claim rules of the preset to be unchanged")
+	}
+}
+
+func TestFederatedIdentityMatchWarnings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		issuer  string
+		subject string
+		want    []string
+	}{
+		{name: "safe", issuer: "https://token.actions.githubusercontent.com", subject: "repo:example/infra:*"},
+		{name: "wildcard-subject", issuer: "https://gitlab.com", subject: "*", want: []string{"Subject matches any identity token"}},
+		{name: "repeated-wildcard-subject", issuer: "https://gitlab.com", subject: "**", want: []string{"Subject matches any identity token"}},
+		{name: "http-issuer", issuer: "http://gitlab.example.com", subject: "project_path:group/project:*", want: []string{"Issuer is not an HTTPS URL"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, d := range federatedIdentityMatchWarnings(tt.issuer, tt.subject) {
+				got = append(got, d.Summary())
+			}
+			if err := assertEqual(tt.want, got, "wrong warnings"); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
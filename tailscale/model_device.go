@@ -5,8 +5,10 @@ package tailscale
 
 import (
 	"context"
+	"slices"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -19,12 +21,16 @@ type deviceDataSourceModel struct {
 	Name     types.String `tfsdk:"name"`
 
 	Addresses                 types.List   `tfsdk:"addresses"`
+	AdvertisedRoutes          types.Set    `tfsdk:"advertised_routes"`
 	Authorized                types.Bool   `tfsdk:"authorized"`
 	BlocksIncomingConnections types.Bool   `tfsdk:"blocks_incoming_connections"`
+	ClientConnectivity        types.Object `tfsdk:"client_connectivity"`
 	ClientVersion             types.String `tfsdk:"client_version"`
 	Created                   types.String `tfsdk:"created"`
+	EnabledRoutes             types.Set    `tfsdk:"enabled_routes"`
 	Expires                   types.String `tfsdk:"expires"`
 	ID                        types.String `tfsdk:"id"`
+	IsExitNode                types.Bool   `tfsdk:"is_exit_node"`
 	IsExternal                types.Bool   `tfsdk:"is_external"`
 	KeyExpiryDisabled         types.Bool   `tfsdk:"key_expiry_disabled"`
 	LastSeen                  types.String `tfsdk:"last_seen"` // Will be nil if connected_to_control is true.
@@ -32,6 +38,7 @@ type deviceDataSourceModel struct {
 	NodeID                    types.String `tfsdk:"node_id"` // The preferred identifier for a device.
 	NodeKey                   types.String `tfsdk:"node_key"`
 	OS                        types.String `tfsdk:"os"`
+	PostureIdentity           types.Object `tfsdk:"posture_identity"`
 	Tags                      types.Set    `tfsdk:"tags"`
 	TailnetLockError          types.String `tfsdk:"tailnet_lock_error"`
 	TailnetLockKey            types.String `tfsdk:"tailnet_lock_key"`
@@ -39,6 +46,74 @@ type deviceDataSourceModel struct {
 	User                      types.String `tfsdk:"user"`
 }
 
+// clientConnectivityModel describes the client_connectivity attribute of a device.
+type clientConnectivityModel struct {
+	Endpoints             []string                    `tfsdk:"endpoints"`
+	DERP                  string                      `tfsdk:"derp"`
+	MappingVariesByDestIP bool                        `tfsdk:"mapping_varies_by_dest_ip"`
+	DERPLatency           map[string]derpLatencyModel `tfsdk:"derp_latency"`
+	ClientSupports        clientSupportsModel         `tfsdk:"client_supports"`
+}
+
+type derpLatencyModel struct {
+	Preferred bool    `tfsdk:"preferred"`
+	LatencyMs float64 `tfsdk:"latency_ms"`
+}
+
+type clientSupportsModel struct {
+	HairPinning bool `tfsdk:"hair_pinning"`
+	IPv6        bool `tfsdk:"ipv6"`
+	PCP         bool `tfsdk:"pcp"`
+	PMP         bool `tfsdk:"pmp"`
+	UDP         bool `tfsdk:"udp"`
+	UPnP        bool `tfsdk:"upnp"`
+}
+
+// postureIdentityModel describes the posture_identity attribute of a device.
+type postureIdentityModel struct {
+	SerialNumbers     []string `tfsdk:"serial_numbers"`
+	HardwareAddresses []string `tfsdk:"hardware_addresses"`
+	Disabled          bool     `tfsdk:"disabled"`
+}
+
+var clientConnectivityAttrTypes = map[string]attr.Type{
+	"endpoints":                 types.ListType{ElemType: types.StringType},
+	"derp":                      types.StringType,
+	"mapping_varies_by_dest_ip": types.BoolType,
+	"derp_latency": types.MapType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+		"preferred":  types.BoolType,
+		"latency_ms": types.Float64Type,
+	}}},
+	"client_supports": types.ObjectType{AttrTypes: map[string]attr.Type{
+		"hair_pinning": types.BoolType,
+		"ipv6":         types.BoolType,
+		"pcp":          types.BoolType,
+		"pmp":          types.BoolType,
+		"udp":          types.BoolType,
+		"upnp":         types.BoolType,
+	}},
+}
+
+var postureIdentityAttrTypes = map[string]attr.Type{
+	"serial_numbers":     types.ListType{ElemType: types.StringType},
+	"hardware_addresses": types.ListType{ElemType: types.StringType},
+	"disabled":           types.BoolType,
+}
+
+// exitNodeRoutes are the routes a device must advertise, and have enabled,
+// to act as an exit node.
+var exitNodeRoutes = []string{"0.0.0.0/0", "::/0"}
+
+// isExitNode reports whether all exit node routes are contained in routes.
+func isExitNode(routes []string) bool {
+	for _, route := range exitNodeRoutes {
+		if !slices.Contains(routes, route) {
+			return false
+		}
+	}
+	return true
+}
+
 func toDeviceDataSourceModel(ctx context.Context, device *tailscale.Device) (deviceDataSourceModel, diag.Diagnostics) {
 	var lastSeen string
 	if device.LastSeen == nil {
@@ -88,6 +163,62 @@ func toDeviceDataSourceModel(ctx context.Context, device *tailscale.Device) (dev
 	}
 	data.Tags = tags
 
+	advertisedRoutes, diagnostics := types.SetValueFrom(ctx, types.StringType, bools.IfElse(device.AdvertisedRoutes != nil, device.AdvertisedRoutes, []string{}))
+	if diagnostics.HasError() {
+		return deviceDataSourceModel{}, diagnostics
+	}
+	data.AdvertisedRoutes = advertisedRoutes
+
+	enabledRoutes, diagnostics := types.SetValueFrom(ctx, types.StringType, bools.IfElse(device.EnabledRoutes != nil, device.EnabledRoutes, []string{}))
+	if diagnostics.HasError() {
+		return deviceDataSourceModel{}, diagnostics
+	}
+	data.EnabledRoutes = enabledRoutes
+	data.IsExitNode = types.BoolValue(isExitNode(device.AdvertisedRoutes) && isExitNode(device.EnabledRoutes))
+
+	data.ClientConnectivity = types.ObjectNull(clientConnectivityAttrTypes)
+	if cc := device.ClientConnectivity; cc != nil {
+		connectivity := clientConnectivityModel{
+			Endpoints:             bools.IfElse(cc.Endpoints != nil, cc.Endpoints, []string{}),
+			DERP:                  cc.DERP,
+			MappingVariesByDestIP: cc.MappingVariesByDestIP,
+			DERPLatency:           make(map[string]derpLatencyModel, len(cc.DERPLatency)),
+			ClientSupports: clientSupportsModel{
+				HairPinning: cc.ClientSupports.HairPinning,
+				IPv6:        cc.ClientSupports.IPV6,
+				PCP:         cc.ClientSupports.PCP,
+				PMP:         cc.ClientSupports.PMP,
+				UDP:         cc.ClientSupports.UDP,
+				UPnP:        cc.ClientSupports.UPNP,
+			},
+		}
+		for region, latency := range cc.DERPLatency {
+			connectivity.DERPLatency[region] = derpLatencyModel{
+				Preferred: latency.Preferred,
+				LatencyMs: latency.LatencyMilliseconds,
+			}
+		}
+
+		data.ClientConnectivity, diagnostics = types.ObjectValueFrom(ctx, clientConnectivityAttrTypes, connectivity)
+		if diagnostics.HasError() {
+			return deviceDataSourceModel{}, diagnostics
+		}
+	}
+
+	data.PostureIdentity = types.ObjectNull(postureIdentityAttrTypes)
+	if pi := device.PostureIdentity; pi != nil {
+		posture := postureIdentityModel{
+			SerialNumbers:     bools.IfElse(pi.SerialNumbers != nil, pi.SerialNumbers, []string{}),
+			HardwareAddresses: bools.IfElse(pi.HardwareAddresses != nil, pi.HardwareAddresses, []string{}),
+			Disabled:          pi.Disabled,
+		}
+
+		data.PostureIdentity, diagnostics = types.ObjectValueFrom(ctx, postureIdentityAttrTypes, posture)
+		if diagnostics.HasError() {
+			return deviceDataSourceModel{}, diagnostics
+		}
+	}
+
 	return data, diag.Diagnostics{}
 }
 
@@ -170,4 +301,28 @@ var deviceSchema = map[string]schema.Attribute{
 		Description: "The tailnet lock key for the device, if any",
 		Computed:    true,
 	},
+	"advertised_routes": schema.SetAttribute{
+		Description: "The subnet routes advertised by the device",
+		Computed:    true,
+		ElementType: types.StringType,
+	},
+	"enabled_routes": schema.SetAttribute{
+		Description: "The subnet routes enabled for the device",
+		Computed:    true,
+		ElementType: types.StringType,
+	},
+	"is_exit_node": schema.BoolAttribute{
+		Description: "Whether the device is an exit node, i.e. it advertises and has enabled both `0.0.0.0/0` and `::/0`",
+		Computed:    true,
+	},
+	"client_connectivity": schema.ObjectAttribute{
+		Description:    "The device's connectivity as last reported by its client, including its endpoints, DERP region and the latency to each DERP region in milliseconds. Null if the device has not reported its connectivity.",
+		Computed:       true,
+		AttributeTypes: clientConnectivityAttrTypes,
+	},
+	"posture_identity": schema.ObjectAttribute{
+		Description:    "The serial numbers and hardware addresses collected from the device for device posture checks. Null if posture identity collection is not enabled for the tailnet.",
+		Computed:       true,
+		AttributeTypes: postureIdentityAttrTypes,
+	},
 }
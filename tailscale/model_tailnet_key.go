@@ -0,0 +1,146 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"tailscale.com/client/tailscale/v2"
+)
+
+// tailnetKeyModel holds the key metadata shared by the tailnet key resource
+// and data sources.
+type tailnetKeyModel struct {
+	ID            types.String `tfsdk:"id"`
+	Reusable      types.Bool   `tfsdk:"reusable"`
+	Ephemeral     types.Bool   `tfsdk:"ephemeral"`
+	Tags          types.Set    `tfsdk:"tags"`
+	Preauthorized types.Bool   `tfsdk:"preauthorized"`
+	Expiry        types.Int64  `tfsdk:"expiry"`
+	CreatedAt     types.String `tfsdk:"created_at"`
+	ExpiresAt     types.String `tfsdk:"expires_at"`
+	Description   types.String `tfsdk:"description"`
+	Invalid       types.Bool   `tfsdk:"invalid"`
+	UserID        types.String `tfsdk:"user_id"`
+}
+
+// readFrom sets the key metadata from the key returned by the API.
+func (m *tailnetKeyModel) readFrom(ctx context.Context, key *tailscale.Key) diag.Diagnostics {
+	if key.Capabilities.Devices.Create.Tags == nil {
+		key.Capabilities.Devices.Create.Tags = []string{}
+	}
+	tags, diags := types.SetValueFrom(ctx, types.StringType, key.Capabilities.Devices.Create.Tags)
+	if diags.HasError() {
+		return diags
+	}
+
+	m.ID = types.StringValue(key.ID)
+	m.Reusable = types.BoolValue(key.Capabilities.Devices.Create.Reusable)
+	m.Ephemeral = types.BoolValue(key.Capabilities.Devices.Create.Ephemeral)
+	m.Tags = tags
+	m.Preauthorized = types.BoolValue(key.Capabilities.Devices.Create.Preauthorized)
+	m.Expiry = types.Int64PointerValue((*int64)(key.ExpirySeconds))
+	m.CreatedAt = types.StringValue(key.Created.Format(time.RFC3339))
+	m.ExpiresAt = types.StringValue(key.Expires.Format(time.RFC3339))
+	m.Description = types.StringValue(key.Description)
+	m.Invalid = types.BoolValue(key.Invalid)
+	m.UserID = types.StringValue(key.UserID)
+	return diags
+}
+
+// tailnetKeyDataSourceModel describes a key of any type read by the tailnet
+// key data sources. The key itself is never read.
+type tailnetKeyDataSourceModel struct {
+	tailnetKeyModel
+	KeyType types.String `tfsdk:"key_type"`
+	Scopes  types.Set    `tfsdk:"scopes"`
+}
+
+func toTailnetKeyDataSourceModel(ctx context.Context, key *tailscale.Key) (tailnetKeyDataSourceModel, diag.Diagnostics) {
+	var d tailnetKeyDataSourceModel
+	diags := d.readFrom(ctx, key)
+	if diags.HasError() {
+		return d, diags
+	}
+
+	d.KeyType = types.StringValue(key.KeyType)
+	d.Tags = SetOfStringValue(ctx, keyTags(key), &diags)
+	d.Scopes = SetOfStringValue(ctx, nonNil(key.Scopes), &diags)
+	return d, diags
+}
+
+// keyTags returns the tags of an auth key, or the tags an OAuth client or
+// federated identity may assign. The tags of the latter are not capabilities
+// of the devices they create, but of the auth keys they are allowed to create.
+func keyTags(key *tailscale.Key) []string {
+	if key.KeyType == "auth" {
+		return nonNil(key.Capabilities.Devices.Create.Tags)
+	}
+	return nonNil(key.Tags)
+}
+
+// nonNil returns s, or an empty slice if s is nil.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+var tailnetKeySchema = map[string]schema.Attribute{
+	"key_type": schema.StringAttribute{
+		Description: "The type of the key: `auth` for auth keys, `api` for API access tokens, `client` for OAuth clients and `federated` for federated identities.",
+		Computed:    true,
+	},
+	"description": schema.StringAttribute{
+		Description: "The description of the key.",
+		Computed:    true,
+	},
+	"reusable": schema.BoolAttribute{
+		Description: "Whether the key is reusable. Only set for auth keys.",
+		Computed:    true,
+	},
+	"ephemeral": schema.BoolAttribute{
+		Description: "Whether the devices authenticated by the key are ephemeral. Only set for auth keys.",
+		Computed:    true,
+	},
+	"preauthorized": schema.BoolAttribute{
+		Description: "Whether the devices authenticated by the key are authorized by default. Only set for auth keys.",
+		Computed:    true,
+	},
+	"tags": schema.SetAttribute{
+		Description: "The tags applied to the devices authenticated by an auth key, or the tags an OAuth client or federated identity may assign.",
+		Computed:    true,
+		ElementType: types.StringType,
+	},
+	"scopes": schema.SetAttribute{
+		Description: "The scopes granted to an OAuth client or federated identity.",
+		Computed:    true,
+		ElementType: types.StringType,
+	},
+	"expiry": schema.Int64Attribute{
+		Description: "The expiry of the key in seconds, if set when the key was created.",
+		Computed:    true,
+	},
+	"created_at": schema.StringAttribute{
+		Description: "The creation timestamp of the key in RFC3339 format.",
+		Computed:    true,
+	},
+	"expires_at": schema.StringAttribute{
+		Description: "The expiry timestamp of the key in RFC3339 format.",
+		Computed:    true,
+	},
+	"invalid": schema.BoolAttribute{
+		Description: "Whether the key is invalid, e.g. because it has expired or has been revoked.",
+		Computed:    true,
+	},
+	"user_id": schema.StringAttribute{
+		Description: "The ID of the user who created the key, empty for keys created by OAuth clients.",
+		Computed:    true,
+	},
+}
@@ -11,6 +11,17 @@ import (
 	"tailscale.com/client/tailscale/v2"
 )
 
+// userRoles are the roles a user can have in a tailnet.
+var userRoles = []string{
+	string(tailscale.UserRoleOwner),
+	string(tailscale.UserRoleMember),
+	string(tailscale.UserRoleAdmin),
+	string(tailscale.UserRoleITAdmin),
+	string(tailscale.UserRoleNetworkAdmin),
+	string(tailscale.UserRoleBillingAdmin),
+	string(tailscale.UserRoleAuditor),
+}
+
 type userDataSourceModel struct {
 	ID                 types.String `tfsdk:"id"`
 	LoginName          types.String `tfsdk:"login_name"`
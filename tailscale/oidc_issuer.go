@@ -0,0 +1,98 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// oidcHTTPClient is used to fetch the discovery documents and signing keys of
+// OIDC issuers.
+var oidcHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// oidcDiscoveryDocument is the subset of an OpenID Provider's configuration
+// needed to verify its identity tokens. See
+// https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata.
+type oidcDiscoveryDocument struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// jsonWebKeySet is the subset of a JSON Web Key Set needed to check that it
+// contains signing keys. See https://datatracker.ietf.org/doc/html/rfc7517#section-5.
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	Use     string `json:"use"`
+}
+
+// validateOIDCIssuer checks that the issuer serves an OpenID Connect discovery
+// document for exactly the given issuer, and that the JSON Web Key Set it
+// refers to can be fetched and contains keys for verifying signatures.
+func validateOIDCIssuer(ctx context.Context, client *http.Client, issuer string) error {
+	discoveryURL := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+
+	var doc oidcDiscoveryDocument
+	if err := getJSON(ctx, client, discoveryURL, &doc); err != nil {
+		return fmt.Errorf("failed to fetch discovery document: %w", err)
+	}
+	if doc.Issuer != issuer {
+		return fmt.Errorf("the discovery document at %s is for the issuer %q, which does not exactly match %q", discoveryURL, doc.Issuer, issuer)
+	}
+	if doc.JWKSURI == "" {
+		return fmt.Errorf("the discovery document at %s does not contain a jwks_uri", discoveryURL)
+	}
+
+	var jwks jsonWebKeySet
+	if err := getJSON(ctx, client, doc.JWKSURI, &jwks); err != nil {
+		return fmt.Errorf("failed to fetch JSON Web Key Set: %w", err)
+	}
+	// Keys without a use may be used for signing.
+	signing := func(key jsonWebKey) bool { return key.Use == "" || key.Use == "sig" }
+	if !slices.ContainsFunc(jwks.Keys, signing) {
+		return fmt.Errorf("the JSON Web Key Set at %s does not contain any signing keys", doc.JWKSURI)
+	}
+
+	return nil
+}
+
+// getJSON fetches the URL and decodes the JSON response body into out.
+func getJSON(ctx context.Context, client *http.Client, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned status %s", url, resp.Status)
+	}
+
+	// Discovery documents and key sets are small, so there's no need to read
+	// more than this.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("GET %s returned invalid JSON: %w", url, err)
+	}
+	return nil
+}
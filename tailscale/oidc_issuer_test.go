@@ -0,0 +1,134 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testOIDCIssuer is a stand-in OIDC issuer that serves a discovery document
+// and JSON Web Key Set.
+type testOIDCIssuer struct {
+	*httptest.Server
+
+	// Issuer is the issuer in the discovery document. Defaults to the URL
+	// of the server.
+	Issuer string
+	// JWKSPath is the path of the JSON Web Key Set in the discovery document.
+	// If empty, the discovery document has no jwks_uri.
+	JWKSPath string
+	// Keys are the keys served in the JSON Web Key Set.
+	Keys []jsonWebKey
+}
+
+func newTestOIDCIssuer(t *testing.T) *testOIDCIssuer {
+	issuer := &testOIDCIssuer{
+		JWKSPath: "/jwks",
+		Keys:     []jsonWebKey{{KeyType: "RSA", Use: "sig"}},
+	}
+	issuer.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			doc := oidcDiscoveryDocument{Issuer: issuer.Issuer}
+			if doc.Issuer == "" {
+				doc.Issuer = issuer.URL
+			}
+			if issuer.JWKSPath != "" {
+				doc.JWKSURI = issuer.URL + issuer.JWKSPath
+			}
+			_ = json.NewEncoder(w).Encode(doc)
+		case "/jwks":
+			_ = json.NewEncoder(w).Encode(jsonWebKeySet{Keys: issuer.Keys})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(issuer.Close)
+	return issuer
+}
+
+func TestValidateOIDCIssuer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		setup   func(issuer *testOIDCIssuer)
+		wantErr string
+	}{
+		{
+			name:  "valid",
+			setup: func(*testOIDCIssuer) {},
+		},
+		{
+			name: "key-without-use",
+			setup: func(issuer *testOIDCIssuer) {
+				issuer.Keys = []jsonWebKey{{KeyType: "EC"}}
+			},
+		},
+		{
+			name: "issuer-mismatch",
+			setup: func(issuer *testOIDCIssuer) {
+				issuer.Issuer = issuer.URL + "/"
+			},
+			wantErr: "does not exactly match",
+		},
+		{
+			name: "no-jwks-uri",
+			setup: func(issuer *testOIDCIssuer) {
+				issuer.JWKSPath = ""
+			},
+			wantErr: "does not contain a jwks_uri",
+		},
+		{
+			name: "jwks-not-found",
+			setup: func(issuer *testOIDCIssuer) {
+				issuer.JWKSPath = "/missing"
+			},
+			wantErr: "failed to fetch JSON Web Key Set",
+		},
+		{
+			name: "no-signing-keys",
+			setup: func(issuer *testOIDCIssuer) {
+				issuer.Keys = []jsonWebKey{{KeyType: "RSA", Use: "enc"}}
+			},
+			wantErr: "does not contain any signing keys",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			issuer := newTestOIDCIssuer(t)
+			tt.setup(issuer)
+
+			err := validateOIDCIssuer(context.Background(), issuer.Client(), issuer.URL)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && err == nil:
+				t.Fatalf("want error containing %q, got nil", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Fatalf("want error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateOIDCIssuerNotFound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+
+	err := validateOIDCIssuer(context.Background(), server.Client(), server.URL)
+	if err == nil || !strings.Contains(err.Error(), "failed to fetch discovery document") {
+		t.Fatalf("want discovery document error, got %v", err)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
@@ -223,8 +224,11 @@ func (p *tailscaleProvider) Resources(_ context.Context) []func() resource.Resou
 		NewACLResource,
 		NewAWSExternalIDResource,
 		NewContactsResource,
+		NewDeviceApprovalRuleResource,
 		NewDeviceAuthorizationResource,
+		NewDeviceInviteResource,
 		NewDeviceKeyResource,
+		NewDeviceLifecycleResource,
 		NewDeviceSubnetRoutesResource,
 		NewDeviceTagsResource,
 		NewDNSConfigurationResource,
@@ -232,12 +236,17 @@ func (p *tailscaleProvider) Resources(_ context.Context) []func() resource.Resou
 		NewDNSPreferencesResource,
 		NewDNSSearchPathsResource,
 		NewDNSSplitNameserversResource,
+		NewExitNodeResource,
 		NewLogstreamConfigurationResource,
 		NewOAuthClientResource,
 		NewPostureIntegrationResource,
 		NewServiceResource,
+		NewStaleDeviceCleanupResource,
 		NewTailnetKeyResource,
 		NewTailnetSettingsResource,
+		NewUserInviteResource,
+		NewUserRoleResource,
+		NewUserStatusResource,
 		NewWebhookResource,
 		NewFederatedIdentityResource,
 	}
@@ -253,6 +262,11 @@ func (p *tailscaleProvider) DataSources(_ context.Context) []func() datasource.D
 		NewMultipleDevicesDataSource,
 		NewServiceDataSource,
 		NewSingleDeviceDataSource,
+		NewSingleTailnetKeyDataSource,
+		NewSubnetRouteOverlapsDataSource,
+		NewMultipleTailnetKeysDataSource,
+		NewMultipleOAuthClientsDataSource,
+		NewMultipleFederatedIdentitiesDataSource,
 	}
 }
 
@@ -327,6 +341,13 @@ func SetOfStringValue(ctx context.Context, value any, diags *diag.Diagnostics) b
 	return v
 }
 
+// IsFullyKnown reports whether the value is known, including all of its
+// elements or attributes if it is a collection or object.
+func IsFullyKnown(ctx context.Context, value attr.Value) bool {
+	v, err := value.ToTerraformValue(ctx)
+	return err == nil && v.IsFullyKnown()
+}
+
 // StringValueNullIfEmpty returns a StringValue of the given input string, or a
 // null StringValue if the input string is empty. Useful for cases where ""
 // being returned from the API is equivalent to an unset / null value in the
@@ -6,9 +6,13 @@ package tailscale
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"tailscale.com/client/tailscale/v2"
 )
 
@@ -52,3 +56,32 @@ type ResourceImportedByID struct {
 func (r *ResourceImportedByID) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// waitForDeviceAttribute is the schema of the wait_for_device attribute shared
+// by resources that modify a single device.
+var waitForDeviceAttribute = schema.StringAttribute{
+	Optional:    true,
+	Description: "If specified, the provider will wait up to the given duration (e.g. `60s`) for the device to register with the tailnet before modifying it. Retries are made every second so this value should be greater than 1s. Useful when the device is created in the same apply, as it may take a few seconds to register.",
+	Validators: []validator.String{
+		retryDeadlineValidator{},
+	},
+}
+
+// waitForDevice waits until the device with the given ID can be fetched from
+// the API, for up to the duration given by waitFor. It returns immediately if
+// waitFor is null.
+func (d *ResourceBase) waitForDevice(ctx context.Context, deviceID string, waitFor types.String) error {
+	if waitFor.IsNull() || waitFor.IsUnknown() {
+		return nil
+	}
+
+	deadline, err := time.ParseDuration(waitFor.ValueString())
+	if err != nil {
+		return fmt.Errorf("failed to parse wait_for_device: %w", err)
+	}
+
+	return retryWithDeadline(ctx, func(ctx context.Context) error {
+		_, err := d.Client.Devices().Get(ctx, deviceID)
+		return err
+	}, deadline, 1*time.Second)
+}
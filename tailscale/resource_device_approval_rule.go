@@ -0,0 +1,286 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"tailscale.com/client/tailscale/v2"
+)
+
+const resourceDeviceApprovalRuleDescription = `The device_approval_rule resource authorizes all pending devices that match a filter. See https://tailscale.com/kb/1099/device-approval for more information.
+
+Matching devices are authorized whenever the resource is created, updated or refreshed, so devices that join the tailnet later are authorized on the next terraform plan or apply. This is useful for autoscaled nodes whose node IDs are not known in advance. The node IDs of the devices authorized by this resource are recorded in the approved_node_ids attribute.
+
+Devices that are already authorized are left untouched, as are devices that were de-authorized after being approved by this resource.
+`
+
+var (
+	_ resource.Resource              = &deviceApprovalRuleResource{}
+	_ resource.ResourceWithConfigure = &deviceApprovalRuleResource{}
+)
+
+type deviceApprovalRuleResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	Tags                 types.Set    `tfsdk:"tags"`
+	User                 types.String `tfsdk:"user"`
+	HostnameRegex        types.String `tfsdk:"hostname_regex"`
+	OS                   types.String `tfsdk:"os"`
+	DeauthorizeOnDestroy types.Bool   `tfsdk:"deauthorize_on_destroy"`
+	ApprovedNodeIDs      types.Set    `tfsdk:"approved_node_ids"`
+}
+
+// NewDeviceApprovalRuleResource returns a new device approval rule resource.
+func NewDeviceApprovalRuleResource() resource.Resource {
+	return &deviceApprovalRuleResource{}
+}
+
+type deviceApprovalRuleResource struct {
+	ResourceBase
+}
+
+func (r deviceApprovalRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_approval_rule"
+}
+
+func (r deviceApprovalRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: resourceDeviceApprovalRuleDescription,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tags": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Only authorize devices that have all of the given tags applied",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"user": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only authorize devices owned by the user with the given login name",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AtLeastOneOf(
+						path.MatchRoot("tags"),
+						path.MatchRoot("user"),
+						path.MatchRoot("hostname_regex"),
+						path.MatchRoot("os"),
+					),
+				},
+			},
+			"hostname_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only authorize devices whose hostname matches the given regular expression",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					regexpValidator{},
+				},
+			},
+			"os": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only authorize devices running the given operating system (e.g. `linux`). Matched case-insensitively.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"deauthorize_on_destroy": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether to de-authorize the devices in approved_node_ids when the resource is destroyed. Defaults to `false`.",
+				Default:     booldefault.StaticBool(false),
+			},
+			"approved_node_ids": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The node IDs of the devices that were authorized by this resource and still exist",
+			},
+		},
+	}
+}
+
+func (r deviceApprovalRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan deviceApprovalRuleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(createUUID())
+	plan.ApprovedNodeIDs = types.SetValueMust(types.StringType, []attr.Value{})
+
+	// The state is set even if approving a device failed, so that the devices
+	// approved before the failure are still recorded.
+	r.approvePendingDevices(ctx, &plan, &resp.Diagnostics)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r deviceApprovalRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state deviceApprovalRuleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.approvePendingDevices(ctx, &state, &resp.Diagnostics)
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r deviceApprovalRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state deviceApprovalRuleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Devices approved under the previous filter remain recorded, so that
+	// they are still de-authorized on destroy.
+	plan.ApprovedNodeIDs = state.ApprovedNodeIDs
+	r.approvePendingDevices(ctx, &plan, &resp.Diagnostics)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r deviceApprovalRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state deviceApprovalRuleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.DeauthorizeOnDestroy.ValueBool() {
+		return
+	}
+
+	var nodeIDs []string
+	resp.Diagnostics.Append(state.ApprovedNodeIDs.ElementsAs(ctx, &nodeIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, nodeID := range nodeIDs {
+		err := r.Client.Devices().SetAuthorized(ctx, nodeID, false)
+		if err != nil && !tailscale.IsNotFound(err) {
+			resp.Diagnostics.AddError(
+				"Failed to update device authorization",
+				"Failed to update authorization for device with ID "+nodeID+": "+err.Error(),
+			)
+		}
+	}
+}
+
+// approvePendingDevices authorizes the pending devices matching the rule and
+// adds them to its approved node IDs. Devices that were already approved are
+// not authorized again, and devices that no longer exist are removed from the
+// approved node IDs. If authorizing a device fails, the devices approved until
+// then are still added.
+func (r deviceApprovalRuleResource) approvePendingDevices(ctx context.Context, rule *deviceApprovalRuleResourceModel, diags *diag.Diagnostics) {
+	var tags, approved []string
+	diags.Append(rule.Tags.ElementsAs(ctx, &tags, false)...)
+	diags.Append(rule.ApprovedNodeIDs.ElementsAs(ctx, &approved, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	filter := deviceFilter{
+		Tags: tags,
+		User: rule.User.ValueString(),
+		OS:   rule.OS.ValueString(),
+	}
+	if hostnameRegex := rule.HostnameRegex.ValueString(); hostnameRegex != "" {
+		re, err := regexp.Compile(hostnameRegex)
+		if err != nil {
+			diags.AddError("Failed to parse hostname_regex", err.Error())
+			return
+		}
+		filter.HostnameRegexp = re
+	}
+
+	devices, err := r.Client.Devices().List(ctx)
+	if err != nil {
+		diags.AddError("Failed to fetch devices", err.Error())
+		return
+	}
+
+	if approved == nil {
+		approved = []string{}
+	}
+
+	var existing []string
+	for _, device := range devices {
+		existing = append(existing, device.NodeID)
+		if device.Authorized || slices.Contains(approved, device.NodeID) || !filter.matches(&device) {
+			continue
+		}
+
+		if err := r.Client.Devices().SetAuthorized(ctx, device.NodeID, true); err != nil {
+			diags.AddError(
+				"Failed to update device authorization",
+				"Failed to update authorization for device with ID "+device.NodeID+": "+err.Error(),
+			)
+			rule.ApprovedNodeIDs = SetOfStringValue(ctx, approved, diags)
+			return
+		}
+		approved = append(approved, device.NodeID)
+	}
+
+	approved = slices.DeleteFunc(approved, func(nodeID string) bool {
+		return !slices.Contains(existing, nodeID)
+	})
+	rule.ApprovedNodeIDs = SetOfStringValue(ctx, approved, diags)
+}
+
+// deviceFilter matches devices by their tags, user, hostname and operating
+// system. Empty criteria match every device.
+type deviceFilter struct {
+	// Tags must all be applied to the device.
+	Tags           []string
+	User           string
+	HostnameRegexp *regexp.Regexp
+	OS             string
+}
+
+// matches reports whether the device matches all of the filter's criteria.
+func (f deviceFilter) matches(device *tailscale.Device) bool {
+	if len(missingElements(f.Tags, device.Tags)) > 0 {
+		return false
+	}
+	if f.User != "" && device.User != f.User {
+		return false
+	}
+	if f.HostnameRegexp != nil && !f.HostnameRegexp.MatchString(device.Hostname) {
+		return false
+	}
+	if f.OS != "" && !strings.EqualFold(device.OS, f.OS) {
+		return false
+	}
+	return true
+}
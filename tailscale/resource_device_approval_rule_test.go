@@ -0,0 +1,128 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"tailscale.com/client/tailscale/v2"
+)
+
+func TestDeviceFilterMatches(t *testing.T) {
+	t.Parallel()
+
+	device := &tailscale.Device{
+		Hostname: "ci-runner-12",
+		User:     "ci@example.com",
+		OS:       "linux",
+		Tags:     []string{"tag:ci", "tag:autoscaled"},
+	}
+
+	tests := []struct {
+		name   string
+		filter deviceFilter
+		want   bool
+	}{
+		{name: "empty", filter: deviceFilter{}, want: true},
+		{name: "all-criteria", filter: deviceFilter{Tags: []string{"tag:ci"}, User: "ci@example.com", HostnameRegexp: regexp.MustCompile(`^ci-runner-\d+$`), OS: "Linux"}, want: true},
+		{name: "missing-tag", filter: deviceFilter{Tags: []string{"tag:ci", "tag:prod"}}, want: false},
+		{name: "other-user", filter: deviceFilter{User: "someone@example.com"}, want: false},
+		{name: "hostname-mismatch", filter: deviceFilter{HostnameRegexp: regexp.MustCompile(`^web-`)}, want: false},
+		{name: "other-os", filter: deviceFilter{OS: "windows"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.matches(device); got != tt.want {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProvider_DeviceApprovalRule(t *testing.T) {
+	devices := []tailscale.Device{
+		{NodeID: "node-1", Hostname: "ci-runner-1", Tags: []string{"tag:ci"}},
+		{NodeID: "node-2", Hostname: "ci-runner-2", Tags: []string{"tag:ci"}, Authorized: true},
+		{NodeID: "node-3", Hostname: "web-1", Tags: []string{"tag:ci"}},
+		{NodeID: "node-4", Hostname: "ci-runner-4"},
+	}
+
+	const cfg = `
+		resource "tailscale_device_approval_rule" "ci" {
+			tags           = ["tag:ci"]
+			hostname_regex = "^ci-runner-"
+		}`
+
+	// The devices are never marked as authorized, as if they were
+	// de-authorized straight after being approved.
+	var authorized []string
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.HandleRequest = func(method, path string) TestResponse {
+				if method == http.MethodGet {
+					return TestResponse{Code: http.StatusOK, Body: map[string][]tailscale.Device{"devices": devices}}
+				}
+				authorized = append(authorized, strings.TrimSuffix(strings.TrimPrefix(path, "/api/v2/device/"), "/authorized"))
+				return TestResponse{Code: http.StatusOK, Body: map[string]any{}}
+			}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tailscale_device_approval_rule.ci", "approved_node_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr("tailscale_device_approval_rule.ci", "approved_node_ids.*", "node-1"),
+					resource.TestCheckResourceAttr("tailscale_device_approval_rule.ci", "deauthorize_on_destroy", "false"),
+				),
+			},
+			{
+				// Refreshing the state does not authorize the device again.
+				Config: cfg,
+				Check: func(_ *terraform.State) error {
+					if want := []string{"node-1"}; !reflect.DeepEqual(authorized, want) {
+						return fmt.Errorf("want devices %v to be authorized once, got %v", want, authorized)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestProvider_DeviceApprovalRule_InvalidConfig(t *testing.T) {
+	testCases := []expectedErrorTestCase{
+		{
+			Name:        "no-filter",
+			Config:      `resource "tailscale_device_approval_rule" "example" {}`,
+			ExpectError: regexp.MustCompile(`At least one attribute out of\s+\[tags,user,hostname_regex,os\] must be specified`),
+		},
+		{
+			Name:        "empty-tags",
+			Config:      `resource "tailscale_device_approval_rule" "example" { tags = [] }`,
+			ExpectError: regexp.MustCompile(`Attribute tags set must contain at least 1\s+elements`),
+		},
+		{
+			Name: "invalid-hostname-regex",
+			Config: `
+				resource "tailscale_device_approval_rule" "example" {
+					hostname_regex = "ci-runner-("
+				}
+			`,
+			ExpectError: regexp.MustCompile(`Attribute hostname_regex value must be a valid regular expression`),
+		},
+	}
+
+	runExpectedErrorTests(t, testCases)
+}
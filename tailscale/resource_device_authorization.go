@@ -25,6 +25,8 @@ type deviceAuthorizationResourceModel struct {
 	ID         types.String `tfsdk:"id"`
 	DeviceID   types.String `tfsdk:"device_id"`
 	Authorized types.Bool   `tfsdk:"authorized"`
+
+	WaitForDevice types.String `tfsdk:"wait_for_device"`
 }
 
 // NewDeviceAuthorizationResource returns a new device authorization resource.
@@ -61,6 +63,7 @@ func (d deviceAuthorizationResource) Schema(_ context.Context, _ resource.Schema
 				Required:    true,
 				Description: "Whether or not the device is authorized",
 			},
+			"wait_for_device": waitForDeviceAttribute,
 		},
 	}
 }
@@ -112,6 +115,15 @@ func (d deviceAuthorizationResource) Create(ctx context.Context, req resource.Cr
 	}
 
 	deviceID := plan.DeviceID.ValueString()
+
+	if err := d.waitForDevice(ctx, deviceID, plan.WaitForDevice); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to wait for device",
+			"Failed to fetch device with ID "+deviceID+": "+err.Error(),
+		)
+		return
+	}
+
 	authorized := plan.Authorized.ValueBool()
 
 	if err := d.Client.Devices().SetAuthorized(ctx, deviceID, authorized); err != nil {
@@ -0,0 +1,260 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"tailscale.com/client/tailscale/v2"
+)
+
+const resourceDeviceInviteDescription = `The device_invite resource shares a device with users outside of the tailnet. See https://tailscale.com/kb/1084/sharing for more information.
+
+The invite URL can be sent to the recipient, or the invite can be emailed to them by setting email. Destroying the resource revokes the invite. Once an invite has been accepted, revoking it does not remove the device from the recipient's tailnet.
+`
+
+var (
+	_ resource.Resource                = &deviceInviteResource{}
+	_ resource.ResourceWithConfigure   = &deviceInviteResource{}
+	_ resource.ResourceWithImportState = &deviceInviteResource{}
+)
+
+// deviceInvite is an invite to share a device, as returned by the device
+// invites API.
+type deviceInvite struct {
+	ID            string `json:"id"`
+	DeviceID      string `json:"deviceId"`
+	MultiUse      bool   `json:"multiUse"`
+	AllowExitNode bool   `json:"allowExitNode"`
+	Email         string `json:"email"`
+	InviteURL     string `json:"inviteUrl"`
+	Accepted      bool   `json:"accepted"`
+	AcceptedBy    *struct {
+		LoginName string `json:"loginName"`
+	} `json:"acceptedBy"`
+}
+
+// createDeviceInviteRequest is the request body for a single invite when
+// creating device invites.
+type createDeviceInviteRequest struct {
+	MultiUse      bool   `json:"multiUse"`
+	AllowExitNode bool   `json:"allowExitNode"`
+	Email         string `json:"email,omitempty"`
+}
+
+type deviceInviteResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	DeviceID      types.String `tfsdk:"device_id"`
+	Email         types.String `tfsdk:"email"`
+	AllowExitNode types.Bool   `tfsdk:"allow_exit_node"`
+	MultiUse      types.Bool   `tfsdk:"multi_use"`
+	InviteURL     types.String `tfsdk:"invite_url"`
+	Accepted      types.Bool   `tfsdk:"accepted"`
+	AcceptedBy    types.String `tfsdk:"accepted_by"`
+}
+
+// NewDeviceInviteResource returns a new device invite resource.
+func NewDeviceInviteResource() resource.Resource {
+	return &deviceInviteResource{}
+}
+
+type deviceInviteResource struct {
+	ResourceBase
+	ResourceImportedByID
+}
+
+func (r deviceInviteResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_invite"
+}
+
+func (r deviceInviteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: resourceDeviceInviteDescription,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the invite",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"device_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The device to share",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"email": schema.StringAttribute{
+				Optional:    true,
+				Description: "The email address to send the invite to. If not set, the invite URL must be shared with the recipient manually.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"allow_exit_node": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the recipient may use the device as an exit node. Defaults to `false`.",
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"multi_use": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the invite can be accepted by more than one user. Defaults to `false`.",
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"invite_url": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The URL at which the invite can be accepted",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"accepted": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the invite has been accepted",
+			},
+			"accepted_by": schema.StringAttribute{
+				Computed:    true,
+				Description: "The login name of the user who accepted the invite",
+			},
+		},
+	}
+}
+
+func (r deviceInviteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan deviceInviteResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deviceID := plan.DeviceID.ValueString()
+	request := []createDeviceInviteRequest{{
+		MultiUse:      plan.MultiUse.ValueBool(),
+		AllowExitNode: plan.AllowExitNode.ValueBool(),
+		Email:         plan.Email.ValueString(),
+	}}
+
+	var invites []deviceInvite
+	if err := apiRequest(ctx, r.Client, http.MethodPost, request, &invites, "device", deviceID, "device-invites"); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create device invite",
+			"Failed to create invite for device with ID "+deviceID+": "+err.Error(),
+		)
+		return
+	}
+	if len(invites) != 1 {
+		resp.Diagnostics.AddError(
+			"Failed to create device invite",
+			"Expected the API to return exactly one invite for device with ID "+deviceID,
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(invites[0].ID)
+	plan.readFrom(&invites[0])
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r deviceInviteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state deviceInviteResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inviteID := state.ID.ValueString()
+
+	var invite deviceInvite
+	if err := apiRequest(ctx, r.Client, http.MethodGet, nil, &invite, "device-invites", inviteID); err != nil {
+		// If the invite has been revoked outside of Terraform, remove it from the state so it is created again.
+		if tailscale.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Failed to fetch device invite",
+			"Failed to fetch device invite with ID "+inviteID+": "+err.Error(),
+		)
+		return
+	}
+
+	// The device ID is only set from the API on import, so that it keeps
+	// matching the configuration if it refers to the device by its legacy ID.
+	if state.DeviceID.IsNull() {
+		state.DeviceID = types.StringValue(invite.DeviceID)
+	}
+	state.Email = StringValueNullIfEmpty(invite.Email)
+	state.AllowExitNode = types.BoolValue(invite.AllowExitNode)
+	state.MultiUse = types.BoolValue(invite.MultiUse)
+	state.readFrom(&invite)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r deviceInviteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All configurable attributes require the invite to be replaced, so the
+	// plan only carries over the computed attributes from the state.
+	var plan deviceInviteResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r deviceInviteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state deviceInviteResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inviteID := state.ID.ValueString()
+
+	err := apiRequest(ctx, r.Client, http.MethodDelete, nil, nil, "device-invites", inviteID)
+	if err != nil && !tailscale.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Failed to delete device invite",
+			"Failed to delete device invite with ID "+inviteID+": "+err.Error(),
+		)
+	}
+}
+
+// readFrom sets the computed attributes of the model from the invite.
+func (m *deviceInviteResourceModel) readFrom(invite *deviceInvite) {
+	m.InviteURL = types.StringValue(invite.InviteURL)
+	m.Accepted = types.BoolValue(invite.Accepted)
+	m.AcceptedBy = types.StringNull()
+	if invite.AcceptedBy != nil {
+		m.AcceptedBy = StringValueNullIfEmpty(invite.AcceptedBy.LoginName)
+	}
+}
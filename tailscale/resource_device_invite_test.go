@@ -0,0 +1,68 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestProvider_TailscaleDeviceInvite(t *testing.T) {
+	const testDeviceInvite = `
+		resource "tailscale_device_invite" "test_invite" {
+			device_id       = "device1CNTRL"
+			email           = "vendor@example.com"
+			allow_exit_node = true
+		}`
+
+	invite := deviceInvite{
+		ID:            "invite1",
+		DeviceID:      "device1CNTRL",
+		AllowExitNode: true,
+		Email:         "vendor@example.com",
+		InviteURL:     "https://login.tailscale.com/admin/invite/abc",
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.HandleRequest = func(method, path string) TestResponse {
+				switch method {
+				case http.MethodPost:
+					return TestResponse{Code: http.StatusOK, Body: []deviceInvite{invite}}
+				case http.MethodDelete:
+					return TestResponse{Code: http.StatusOK, Body: map[string]any{}}
+				}
+				return TestResponse{Code: http.StatusOK, Body: invite}
+			}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		CheckDestroy: func(_ *terraform.State) error {
+			if testServer.Method != http.MethodDelete || testServer.Path != "/api/v2/device-invites/invite1" {
+				return fmt.Errorf("want invite to be revoked, got last request %s %s", testServer.Method, testServer.Path)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testDeviceInvite,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tailscale_device_invite.test_invite", "id", "invite1"),
+					resource.TestCheckResourceAttr("tailscale_device_invite.test_invite", "invite_url", invite.InviteURL),
+					resource.TestCheckResourceAttr("tailscale_device_invite.test_invite", "multi_use", "false"),
+					resource.TestCheckResourceAttr("tailscale_device_invite.test_invite", "accepted", "false"),
+				),
+			},
+			{
+				ResourceName:      "tailscale_device_invite.test_invite",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,172 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"tailscale.com/client/tailscale/v2"
+)
+
+const resourceDeviceLifecycleDescription = `The device_lifecycle resource ties the lifetime of a Tailscale device to Terraform. Destroying this resource deletes the device from the tailnet, so that devices of destroyed machines do not linger in the admin console and their hostnames can be reused.
+
+The device is not modified when the resource is created. If the device has already been deleted when the resource is destroyed, destroying the resource succeeds.
+`
+
+var (
+	_ resource.Resource                = &deviceLifecycleResource{}
+	_ resource.ResourceWithConfigure   = &deviceLifecycleResource{}
+	_ resource.ResourceWithImportState = &deviceLifecycleResource{}
+)
+
+type deviceLifecycleResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	DeviceID types.String `tfsdk:"device_id"`
+
+	WaitForDevice types.String `tfsdk:"wait_for_device"`
+}
+
+// NewDeviceLifecycleResource returns a new device lifecycle resource.
+func NewDeviceLifecycleResource() resource.Resource {
+	return &deviceLifecycleResource{}
+}
+
+type deviceLifecycleResource struct {
+	ResourceBase
+	ResourceImportedByID
+}
+
+func (d deviceLifecycleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_lifecycle"
+}
+
+func (d deviceLifecycleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: resourceDeviceLifecycleDescription,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"device_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The device to delete when this resource is destroyed",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_device": waitForDeviceAttribute,
+		},
+	}
+}
+
+func (d deviceLifecycleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan deviceLifecycleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deviceID := plan.DeviceID.ValueString()
+
+	if err := d.waitForDevice(ctx, deviceID, plan.WaitForDevice); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to wait for device",
+			"Failed to fetch device with ID "+deviceID+": "+err.Error(),
+		)
+		return
+	}
+
+	// Make sure the device exists, so that a typo in the device ID does not go
+	// unnoticed until the resource is destroyed.
+	if _, err := d.Client.Devices().Get(ctx, deviceID); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to fetch device",
+			"Failed to fetch device with ID "+deviceID+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(deviceID)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d deviceLifecycleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state deviceLifecycleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deviceID := state.ID.ValueString()
+
+	device, err := d.Client.Devices().Get(ctx, deviceID)
+	if err != nil {
+		// If the device is not found, remove from the state so we can create it again.
+		if tailscale.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Failed to fetch device",
+			"Failed to fetch device with ID "+deviceID+": "+err.Error(),
+		)
+		return
+	}
+
+	// If the device lookup succeeds and the state ID is not the same as the legacy ID, we can assume the ID is the node ID.
+	canonicalDeviceID := device.ID
+	if device.ID != deviceID {
+		canonicalDeviceID = device.NodeID
+	}
+
+	state.DeviceID = types.StringValue(canonicalDeviceID)
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d deviceLifecycleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Only wait_for_device can change without replacing the resource, and it
+	// has no effect once the resource is created.
+	var plan deviceLifecycleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d deviceLifecycleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state deviceLifecycleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deviceID := state.DeviceID.ValueString()
+
+	err := d.Client.Devices().Delete(ctx, deviceID)
+	// The device may already have been deleted, e.g. if it was ephemeral, so we can ignore not-found errors.
+	if err != nil && !tailscale.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Failed to delete device",
+			"Failed to delete device with ID "+deviceID+": "+err.Error(),
+		)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"tailscale.com/client/tailscale/v2"
+)
+
+func TestProvider_TailscaleDeviceLifecycle(t *testing.T) {
+	const testDeviceLifecycle = `
+		resource "tailscale_device_lifecycle" "test_lifecycle" {
+			device_id = "device1CNTRL"
+		}`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.HandleRequest = func(method, path string) TestResponse {
+				// The device has already been deleted by the time the
+				// resource is destroyed, which must not be an error.
+				if method == http.MethodDelete {
+					return TestResponse{Code: http.StatusNotFound, Body: map[string]string{"message": "not found"}}
+				}
+				return TestResponse{Code: http.StatusOK, Body: tailscale.Device{ID: "device1CNTRL"}}
+			}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		CheckDestroy: func(_ *terraform.State) error {
+			if testServer.Method != http.MethodDelete || testServer.Path != "/api/v2/device/device1CNTRL" {
+				return fmt.Errorf("want device to be deleted, got last request %s %s", testServer.Method, testServer.Path)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testDeviceLifecycle,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tailscale_device_lifecycle.test_lifecycle", "id", "device1CNTRL"),
+					resource.TestCheckResourceAttr("tailscale_device_lifecycle.test_lifecycle", "device_id", "device1CNTRL"),
+				),
+			},
+		},
+	})
+}
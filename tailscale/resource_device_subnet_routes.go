@@ -5,12 +5,19 @@ package tailscale
 
 import (
 	"context"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"tailscale.com/client/tailscale/v2"
 )
@@ -19,21 +26,37 @@ const resourceDeviceSubnetRoutesDescription = `The device_subnet_routes resource
 
 Routes must be both advertised and enabled for a device to act as a subnet router or exit node. Routes must be advertised directly from the device: advertised routes cannot be managed through Terraform. If a device is advertising routes, they are not exposed to traffic until they are enabled. Conversely, if routes are enabled before they are advertised, they are not available for routing until the device in question is advertising them.
 
-Note: all routes enabled for the device through the admin console or autoApprovers in the ACL must be explicitly added to the routes attribute of this resource to avoid configuration drift.
+Note: in the default authoritative mode, all routes enabled for the device through the admin console or autoApprovers in the ACL must be explicitly added to the routes attribute of this resource to avoid configuration drift. Set mode to additive to only manage the routes listed in this resource and leave any other enabled routes untouched.
 `
 
 var (
 	_ resource.Resource                = &deviceSubnetRoutesResource{}
 	_ resource.ResourceWithConfigure   = &deviceSubnetRoutesResource{}
 	_ resource.ResourceWithImportState = &deviceSubnetRoutesResource{}
+	_ resource.ResourceWithModifyPlan  = &deviceSubnetRoutesResource{}
 )
 
 type deviceSubnetRoutesModel struct {
 	ID       types.String `tfsdk:"id"`
 	DeviceID types.String `tfsdk:"device_id"`
 	Routes   types.Set    `tfsdk:"routes"`
+	Mode     types.String `tfsdk:"mode"`
+
+	PreexistingRoutes types.Set `tfsdk:"preexisting_routes"`
+
+	RequireAdvertised types.Bool   `tfsdk:"require_advertised"`
+	WaitForDevice     types.String `tfsdk:"wait_for_device"`
 }
 
+const (
+	// subnetRoutesModeAuthoritative makes the resource enable exactly the
+	// configured routes, disabling any others.
+	subnetRoutesModeAuthoritative = "authoritative"
+	// subnetRoutesModeAdditive makes the resource only ensure that the
+	// configured routes are enabled, preserving any others.
+	subnetRoutesModeAdditive = "additive"
+)
+
 func NewDeviceSubnetRoutesResource() resource.Resource {
 	return &deviceSubnetRoutesResource{}
 }
@@ -75,8 +98,30 @@ func (d deviceSubnetRoutesResource) Schema(_ context.Context, _ resource.SchemaR
 			},
 			"routes": schema.SetAttribute{
 				Required:    true,
-				Description: "The subnet routes that are enabled to be routed by a device",
-				ElementType: types.StringType,
+				Description: "The subnet routes that are enabled to be routed by a device. Routes are compared in their masked form, so `10.0.0.1/24` is treated as `10.0.0.0/24`.",
+				ElementType: cidrType{},
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(cidrValidator{}),
+				},
+			},
+			"require_advertised": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, planning fails when any of the routes being added are not currently advertised by the device. Otherwise, a warning is shown for such routes, as they are not available for routing until the device advertises them. The check is skipped when the device does not exist yet and `wait_for_device` is set.",
+			},
+			"wait_for_device": waitForDeviceAttribute,
+			"mode": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "How the routes are applied to the device. In `authoritative` mode, the device has exactly the given routes enabled and any other enabled routes are disabled. In `additive` mode, the given routes are enabled alongside any routes enabled elsewhere, such as through autoApprovers or the admin console, and only the given routes that this resource enabled are disabled when they are removed or the resource is destroyed. Defaults to `authoritative`.",
+				Default:     stringdefault.StaticString(subnetRoutesModeAuthoritative),
+				Validators: []validator.String{
+					stringvalidator.OneOf(subnetRoutesModeAuthoritative, subnetRoutesModeAdditive),
+				},
+			},
+			"preexisting_routes": schema.SetAttribute{
+				Computed:    true,
+				Description: "In `additive` mode, the given routes that were already enabled when this resource started managing them. These routes are left enabled when they are removed from `routes` or the resource is destroyed.",
+				ElementType: cidrType{},
 			},
 		},
 	}
@@ -112,7 +157,31 @@ func (d deviceSubnetRoutesResource) Read(ctx context.Context, req resource.ReadR
 		deviceRoutes.Enabled = []string{}
 	}
 
-	state.Routes = SetOfStringValue(ctx, deviceRoutes.Enabled, &resp.Diagnostics)
+	// The mode is not known when importing the resource.
+	if state.Mode.IsNull() {
+		state.Mode = types.StringValue(subnetRoutesModeAuthoritative)
+	}
+
+	enabled := deviceRoutes.Enabled
+	if state.Mode.ValueString() == subnetRoutesModeAdditive {
+		// Only the routes managed by this resource are tracked, so that routes
+		// enabled elsewhere do not show up as drift.
+		var managed []string
+		resp.Diagnostics.Append(state.Routes.ElementsAs(ctx, &managed, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		enabled = []string{}
+		managed = normalizeCIDRs(managed)
+		for _, route := range deviceRoutes.Enabled {
+			if slices.Contains(managed, normalizeCIDR(route)) {
+				enabled = append(enabled, route)
+			}
+		}
+	}
+
+	state.Routes, diags = types.SetValueFrom(ctx, cidrType{}, enabled)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -130,6 +199,15 @@ func (d deviceSubnetRoutesResource) Create(ctx context.Context, req resource.Cre
 	}
 
 	deviceID := plan.DeviceID.ValueString()
+
+	if err := d.waitForDevice(ctx, deviceID, plan.WaitForDevice); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to wait for device",
+			"Failed to fetch device with ID "+deviceID+": "+err.Error(),
+		)
+		return
+	}
+
 	routes := plan.Routes
 
 	subnetRoutes := make([]string, len(routes.Elements()))
@@ -138,6 +216,26 @@ func (d deviceSubnetRoutesResource) Create(ctx context.Context, req resource.Cre
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	subnetRoutes = normalizeCIDRs(subnetRoutes)
+
+	plan.PreexistingRoutes = types.SetNull(cidrType{})
+	if plan.Mode.ValueString() == subnetRoutesModeAdditive {
+		var preexisting []string
+		var err error
+		subnetRoutes, preexisting, err = d.additiveRoutes(ctx, deviceID, nil, nil, subnetRoutes)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to fetch device subnet routes",
+				"Failed to fetch subnet routes for device with ID "+deviceID+": "+err.Error(),
+			)
+			return
+		}
+		plan.PreexistingRoutes, diags = types.SetValueFrom(ctx, cidrType{}, preexisting)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	if err := d.Client.Devices().SetSubnetRoutes(ctx, deviceID, subnetRoutes); err != nil {
 		resp.Diagnostics.AddError(
@@ -153,9 +251,10 @@ func (d deviceSubnetRoutesResource) Create(ctx context.Context, req resource.Cre
 }
 
 func (d deviceSubnetRoutesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan deviceSubnetRoutesModel
+	var plan, state deviceSubnetRoutesModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -169,6 +268,34 @@ func (d deviceSubnetRoutesResource) Update(ctx context.Context, req resource.Upd
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	subnetRoutes = normalizeCIDRs(subnetRoutes)
+
+	plan.PreexistingRoutes = types.SetNull(cidrType{})
+	if plan.Mode.ValueString() == subnetRoutesModeAdditive {
+		// Routes that are no longer configured are disabled, leaving routes
+		// enabled elsewhere as they are.
+		var previous, preexisting []string
+		resp.Diagnostics.Append(state.Routes.ElementsAs(ctx, &previous, false)...)
+		resp.Diagnostics.Append(state.PreexistingRoutes.ElementsAs(ctx, &preexisting, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var err error
+		subnetRoutes, preexisting, err = d.additiveRoutes(ctx, deviceID, normalizeCIDRs(previous), normalizeCIDRs(preexisting), subnetRoutes)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to fetch device subnet routes",
+				"Failed to fetch subnet routes for device with ID "+deviceID+": "+err.Error(),
+			)
+			return
+		}
+		plan.PreexistingRoutes, diags = types.SetValueFrom(ctx, cidrType{}, preexisting)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	if err := d.Client.Devices().SetSubnetRoutes(ctx, deviceID, subnetRoutes); err != nil {
 		resp.Diagnostics.AddError(
@@ -192,7 +319,27 @@ func (d deviceSubnetRoutesResource) Delete(ctx context.Context, req resource.Del
 
 	deviceID := state.DeviceID.ValueString()
 
-	if err := d.Client.Devices().SetSubnetRoutes(ctx, deviceID, []string{}); err != nil {
+	remaining := []string{}
+	if state.Mode.ValueString() == subnetRoutesModeAdditive {
+		var managed, preexisting []string
+		resp.Diagnostics.Append(state.Routes.ElementsAs(ctx, &managed, false)...)
+		resp.Diagnostics.Append(state.PreexistingRoutes.ElementsAs(ctx, &preexisting, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var err error
+		remaining, _, err = d.additiveRoutes(ctx, deviceID, normalizeCIDRs(managed), normalizeCIDRs(preexisting), nil)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to fetch device subnet routes",
+				"Failed to fetch subnet routes for device with ID "+deviceID+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if err := d.Client.Devices().SetSubnetRoutes(ctx, deviceID, remaining); err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to delete device subnet routes",
 			"Failed to delete subnet routes for device with ID "+deviceID+": "+err.Error(),
@@ -200,3 +347,129 @@ func (d deviceSubnetRoutesResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 }
+
+func (d deviceSubnetRoutesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// If the entire plan is null, the resource is planned for destruction.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan deviceSubnetRoutesModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The device may not exist yet, in which case its advertised routes are
+	// checked during the next plan.
+	if plan.DeviceID.IsUnknown() || !IsFullyKnown(ctx, plan.Routes) {
+		return
+	}
+
+	var routes []string
+	resp.Diagnostics.Append(plan.Routes.ElementsAs(ctx, &routes, false)...)
+	if resp.Diagnostics.HasError() || len(routes) == 0 {
+		return
+	}
+	routes = normalizeCIDRs(routes)
+
+	// Routes that are already enabled were checked when they were added, so
+	// only the routes being added are checked.
+	if !req.State.Raw.IsNull() {
+		var state deviceSubnetRoutesModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.DeviceID.Equal(plan.DeviceID) {
+			var previous []string
+			resp.Diagnostics.Append(state.Routes.ElementsAs(ctx, &previous, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			routes = missingElements(routes, normalizeCIDRs(previous))
+			if len(routes) == 0 {
+				return
+			}
+		}
+	}
+
+	deviceID := plan.DeviceID.ValueString()
+	deviceRoutes, err := d.Client.Devices().SubnetRoutes(ctx, deviceID)
+	if err != nil {
+		// The device may register later in the apply when wait_for_device is
+		// set, so its advertised routes can't be checked yet.
+		if tailscale.IsNotFound(err) && (!plan.WaitForDevice.IsNull() || !plan.RequireAdvertised.ValueBool()) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Failed to fetch device subnet routes",
+			"Failed to fetch subnet routes for device with ID "+deviceID+": "+err.Error(),
+		)
+		return
+	}
+
+	notAdvertised := missingElements(routes, normalizeCIDRs(deviceRoutes.Advertised))
+	if len(notAdvertised) == 0 {
+		return
+	}
+
+	summary := "Routes not advertised by device"
+	detail := "The device with ID " + deviceID + " is not currently advertising the following routes: " +
+		strings.Join(notAdvertised, ", ") + ". They will be enabled, but are not available for routing until the device advertises them."
+	if plan.RequireAdvertised.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("routes"), summary, detail)
+		return
+	}
+	resp.Diagnostics.AddAttributeWarning(path.Root("routes"), summary, detail)
+}
+
+// additiveRoutes fetches the routes currently enabled for the device and
+// returns them as they should be enabled in additive mode, see planAdditiveRoutes.
+func (d deviceSubnetRoutesResource) additiveRoutes(ctx context.Context, deviceID string, previous, preexisting, configured []string) ([]string, []string, error) {
+	deviceRoutes, err := d.Client.Devices().SubnetRoutes(ctx, deviceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	routes, preexisting := planAdditiveRoutes(normalizeCIDRs(deviceRoutes.Enabled), previous, preexisting, configured)
+	return routes, preexisting, nil
+}
+
+// planAdditiveRoutes returns the routes to enable given the currently enabled
+// routes, the previously configured routes, those of them that were already
+// enabled before they were configured, and the newly configured routes. Routes
+// that are no longer configured are disabled unless they were already enabled
+// beforehand. It also returns the configured routes that were already enabled
+// beforehand.
+func planAdditiveRoutes(enabled, previous, preexisting, configured []string) (routes, stillPreexisting []string) {
+	stillPreexisting = []string{}
+	for _, route := range preexisting {
+		if slices.Contains(configured, route) {
+			stillPreexisting = append(stillPreexisting, route)
+		}
+	}
+	for _, route := range missingElements(configured, previous) {
+		if slices.Contains(enabled, route) {
+			stillPreexisting = append(stillPreexisting, route)
+		}
+	}
+	slices.Sort(stillPreexisting)
+
+	remove := missingElements(missingElements(previous, configured), preexisting)
+	return mergeRoutes(enabled, remove, configured), slices.Compact(stillPreexisting)
+}
+
+// mergeRoutes returns the sorted union of the routes in enabled that are not in
+// remove and the routes in add.
+func mergeRoutes(enabled, remove, add []string) []string {
+	merged := []string{}
+	for _, route := range enabled {
+		if !slices.Contains(remove, route) {
+			merged = append(merged, route)
+		}
+	}
+	merged = append(merged, add...)
+	slices.Sort(merged)
+	return slices.Compact(merged)
+}
@@ -5,9 +5,12 @@ package tailscale
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -517,3 +520,321 @@ func TestAccTailscaleDeviceSubnetRoutes_LegacyIDToNodeID(t *testing.T) {
 		},
 	})
 }
+
+func TestProvider_DeviceSubnetRoutes_RequireAdvertised(t *testing.T) {
+	const cfg = `
+		resource "tailscale_device_subnet_routes" "test_subnet_routes" {
+			device_id          = "node-123"
+			require_advertised = true
+			routes = [
+				"10.0.1.1/24",
+				"10.0.2.0/24",
+			]
+		}`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.SetResponses([]TestResponse{
+				{Code: http.StatusOK, Body: tailscale.DeviceRoutes{Advertised: []string{"10.0.1.0/24"}}},
+			})
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config:      cfg,
+				ExpectError: regexp.MustCompile(`advertising\s+the\s+following\s+routes:\s+10\.0\.2\.0/24\.`),
+			},
+		},
+	})
+}
+
+func TestProvider_DeviceSubnetRoutes_RequireAdvertisedAddedRoutes(t *testing.T) {
+	const cfg = `
+		resource "tailscale_device_subnet_routes" "test_subnet_routes" {
+			device_id          = "node-123"
+			require_advertised = true
+			routes             = [%s]
+		}`
+
+	advertised := []string{"10.0.1.0/24"}
+	enabled := []string{}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.HandleRequest = func(method, path string) TestResponse {
+				if method == http.MethodPost {
+					var body map[string][]string
+					if err := json.Unmarshal(testServer.Body.Bytes(), &body); err != nil {
+						return TestResponse{Code: http.StatusBadRequest, Body: map[string]any{"message": err.Error()}}
+					}
+					enabled = body["routes"]
+					return TestResponse{Code: http.StatusOK, Body: map[string]any{}}
+				}
+				return TestResponse{Code: http.StatusOK, Body: tailscale.DeviceRoutes{Advertised: advertised, Enabled: enabled}}
+			}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(cfg, `"10.0.1.0/24"`),
+			},
+			{
+				// The enabled route is no longer advertised, which does not
+				// prevent adding another route that is.
+				PreConfig: func() {
+					advertised = []string{"10.0.2.0/24"}
+				},
+				Config: fmt.Sprintf(cfg, `"10.0.1.0/24", "10.0.2.0/24"`),
+				Check:  resource.TestCheckResourceAttr("tailscale_device_subnet_routes.test_subnet_routes", "routes.#", "2"),
+			},
+		},
+	})
+}
+
+func TestProvider_DeviceSubnetRoutes_UnknownRoute(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				// The route is only known once the other resource is created,
+				// so the advertised routes can't be checked yet.
+				Config: `
+					resource "terraform_data" "cidr" {
+						input = "10.0.2.0/24"
+					}
+
+					resource "tailscale_device_subnet_routes" "test_subnet_routes" {
+						device_id          = "node-123"
+						require_advertised = true
+						routes = [
+							"10.0.1.0/24",
+							terraform_data.cidr.output,
+						]
+					}`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestProvider_DeviceSubnetRoutes_RequireAdvertisedWaitForDevice(t *testing.T) {
+	const cfg = `
+		resource "tailscale_device_subnet_routes" "test_subnet_routes" {
+			device_id          = "node-123"
+			require_advertised = true
+			wait_for_device    = "5s"
+			routes             = ["10.0.1.0/24"]
+		}`
+
+	// The device is only found once the provider waits for it during the
+	// apply, and never advertises the route. The check is skipped while the
+	// device does not exist yet, and again once the route is in state.
+	registered := false
+	enabled := []string{}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.HandleRequest = func(method, path string) TestResponse {
+				switch {
+				case strings.HasSuffix(path, "/device/node-123"):
+					registered = true
+					return TestResponse{Code: http.StatusOK, Body: tailscale.Device{NodeID: "node-123"}}
+				case !registered:
+					return TestResponse{Code: http.StatusNotFound, Body: map[string]any{"message": "not found"}}
+				case method == http.MethodPost:
+					var body map[string][]string
+					if err := json.Unmarshal(testServer.Body.Bytes(), &body); err != nil {
+						return TestResponse{Code: http.StatusBadRequest, Body: map[string]any{"message": err.Error()}}
+					}
+					enabled = body["routes"]
+					return TestResponse{Code: http.StatusOK, Body: map[string]any{}}
+				}
+				return TestResponse{Code: http.StatusOK, Body: tailscale.DeviceRoutes{Advertised: []string{}, Enabled: enabled}}
+			}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check:  resource.TestCheckResourceAttr("tailscale_device_subnet_routes.test_subnet_routes", "routes.#", "1"),
+			},
+		},
+	})
+}
+
+func TestProvider_DeviceSubnetRoutes_Additive(t *testing.T) {
+	const cfg = `
+		resource "tailscale_device_subnet_routes" "test_subnet_routes" {
+			device_id = "node-123"
+			mode      = "additive"
+			routes    = [%s]
+		}`
+
+	advertised := []string{"10.0.0.0/24", "10.1.0.0/24", "10.2.0.0/24", "10.3.0.0/24"}
+	// 10.0.0.0/24 is enabled before the resource is created, for example by
+	// autoApprovers, and 10.1.0.0/24 is never managed by the resource.
+	enabled := []string{"10.0.0.0/24", "10.1.0.0/24"}
+
+	checkEnabled := func(want ...string) resource.TestCheckFunc {
+		return func(_ *terraform.State) error {
+			if !reflect.DeepEqual(enabled, want) {
+				return fmt.Errorf("want enabled routes %v, got %v", want, enabled)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.HandleRequest = func(method, path string) TestResponse {
+				if method == http.MethodPost {
+					var body map[string][]string
+					if err := json.Unmarshal(testServer.Body.Bytes(), &body); err != nil {
+						return TestResponse{Code: http.StatusBadRequest, Body: map[string]any{"message": err.Error()}}
+					}
+					enabled = body["routes"]
+					return TestResponse{Code: http.StatusOK, Body: map[string]any{}}
+				}
+				return TestResponse{Code: http.StatusOK, Body: tailscale.DeviceRoutes{Advertised: advertised, Enabled: enabled}}
+			}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		CheckDestroy:             checkEnabled("10.0.0.0/24", "10.1.0.0/24"),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(cfg, `"10.0.0.0/24", "10.2.0.0/24"`),
+				Check: resource.ComposeTestCheckFunc(
+					checkEnabled("10.0.0.0/24", "10.1.0.0/24", "10.2.0.0/24"),
+					resource.TestCheckTypeSetElemAttr("tailscale_device_subnet_routes.test_subnet_routes", "preexisting_routes.*", "10.0.0.0/24"),
+					resource.TestCheckResourceAttr("tailscale_device_subnet_routes.test_subnet_routes", "preexisting_routes.#", "1"),
+				),
+			},
+			{
+				// Removing the route that was already enabled leaves it enabled.
+				Config: fmt.Sprintf(cfg, `"10.2.0.0/24", "10.3.0.0/24"`),
+				Check: resource.ComposeTestCheckFunc(
+					checkEnabled("10.0.0.0/24", "10.1.0.0/24", "10.2.0.0/24", "10.3.0.0/24"),
+					resource.TestCheckResourceAttr("tailscale_device_subnet_routes.test_subnet_routes", "preexisting_routes.#", "0"),
+				),
+			},
+			{
+				// Removing a route enabled by the resource disables it.
+				Config: fmt.Sprintf(cfg, `"10.3.0.0/24"`),
+				Check:  checkEnabled("10.0.0.0/24", "10.1.0.0/24", "10.3.0.0/24"),
+			},
+		},
+	})
+}
+
+func TestPlanAdditiveRoutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		enabled         []string
+		previous        []string
+		preexisting     []string
+		configured      []string
+		wantRoutes      []string
+		wantPreexisting []string
+	}{
+		{
+			name:            "create-records-enabled-routes",
+			enabled:         []string{"10.0.0.0/24", "10.1.0.0/24"},
+			configured:      []string{"10.1.0.0/24", "10.2.0.0/24"},
+			wantRoutes:      []string{"10.0.0.0/24", "10.1.0.0/24", "10.2.0.0/24"},
+			wantPreexisting: []string{"10.1.0.0/24"},
+		},
+		{
+			name:            "update-keeps-preexisting-routes",
+			enabled:         []string{"10.0.0.0/24", "10.1.0.0/24", "10.2.0.0/24"},
+			previous:        []string{"10.1.0.0/24", "10.2.0.0/24"},
+			preexisting:     []string{"10.1.0.0/24"},
+			configured:      []string{"10.3.0.0/24"},
+			wantRoutes:      []string{"10.0.0.0/24", "10.1.0.0/24", "10.3.0.0/24"},
+			wantPreexisting: []string{},
+		},
+		{
+			name:            "update-keeps-preexisting-record",
+			enabled:         []string{"10.1.0.0/24", "10.2.0.0/24"},
+			previous:        []string{"10.1.0.0/24", "10.2.0.0/24"},
+			preexisting:     []string{"10.1.0.0/24"},
+			configured:      []string{"10.1.0.0/24"},
+			wantRoutes:      []string{"10.1.0.0/24"},
+			wantPreexisting: []string{"10.1.0.0/24"},
+		},
+		{
+			name:            "delete",
+			enabled:         []string{"10.0.0.0/24", "10.1.0.0/24", "10.2.0.0/24"},
+			previous:        []string{"10.1.0.0/24", "10.2.0.0/24"},
+			preexisting:     []string{"10.1.0.0/24"},
+			wantRoutes:      []string{"10.0.0.0/24", "10.1.0.0/24"},
+			wantPreexisting: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routes, preexisting := planAdditiveRoutes(tt.enabled, tt.previous, tt.preexisting, tt.configured)
+			if !reflect.DeepEqual(routes, tt.wantRoutes) {
+				t.Errorf("want routes %v, got %v", tt.wantRoutes, routes)
+			}
+			if !reflect.DeepEqual(preexisting, tt.wantPreexisting) {
+				t.Errorf("want preexisting routes %v, got %v", tt.wantPreexisting, preexisting)
+			}
+		})
+	}
+}
+
+func TestMergeRoutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		enabled []string
+		remove  []string
+		add     []string
+		want    []string
+	}{
+		{
+			name:    "add-preserves-other-routes",
+			enabled: []string{"10.0.0.0/24"},
+			add:     []string{"10.1.0.0/24"},
+			want:    []string{"10.0.0.0/24", "10.1.0.0/24"},
+		},
+		{
+			name:    "add-already-enabled",
+			enabled: []string{"10.0.0.0/24", "10.1.0.0/24"},
+			add:     []string{"10.1.0.0/24"},
+			want:    []string{"10.0.0.0/24", "10.1.0.0/24"},
+		},
+		{
+			name:    "remove-previous-routes",
+			enabled: []string{"10.0.0.0/24", "10.1.0.0/24", "10.2.0.0/24"},
+			remove:  []string{"10.1.0.0/24", "10.2.0.0/24"},
+			add:     []string{"10.2.0.0/24"},
+			want:    []string{"10.0.0.0/24", "10.2.0.0/24"},
+		},
+		{
+			name:    "remove-all",
+			enabled: []string{"10.1.0.0/24"},
+			remove:  []string{"10.1.0.0/24"},
+			want:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeRoutes(tt.enabled, tt.remove, tt.add)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
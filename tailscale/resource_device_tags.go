@@ -24,6 +24,8 @@ type deviceTagsResourceModel struct {
 	ID       types.String `tfsdk:"id"`
 	DeviceID types.String `tfsdk:"device_id"`
 	Tags     types.Set    `tfsdk:"tags"`
+
+	WaitForDevice types.String `tfsdk:"wait_for_device"`
 }
 
 // NewDeviceTagsResource returns a new device tags resource.
@@ -59,6 +61,7 @@ func (d deviceTagsResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Description: "The tags to apply to the device",
 				ElementType: types.StringType,
 			},
+			"wait_for_device": waitForDeviceAttribute,
 		},
 	}
 }
@@ -117,6 +120,15 @@ func (d deviceTagsResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	deviceID := plan.DeviceID.ValueString()
+
+	if err := d.waitForDevice(ctx, deviceID, plan.WaitForDevice); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to wait for device",
+			"Failed to fetch device with ID "+deviceID+": "+err.Error(),
+		)
+		return
+	}
+
 	tags := make([]string, len(plan.Tags.Elements()))
 	diags = plan.Tags.ElementsAs(ctx, &tags, false)
 	resp.Diagnostics.Append(diags...)
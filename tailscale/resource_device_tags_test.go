@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -42,6 +43,51 @@ func TestProvider_TailscaleDeviceTags(t *testing.T) {
 	})
 }
 
+func TestProvider_TailscaleDeviceTags_WaitForDevice(t *testing.T) {
+	const testDeviceTagsWaitForDevice = `
+		resource "tailscale_device_tags" "test_tags" {
+			device_id       = "device1CNTRL"
+			tags            = ["tag:server"]
+			wait_for_device = "5s"
+		}`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			// The device is not registered yet on the first attempt.
+			testServer.SetResponses([]TestResponse{
+				{Code: http.StatusNotFound, Body: map[string]string{"message": "not found"}},
+				{Code: http.StatusOK, Body: tailscale.Device{ID: "device1CNTRL", Tags: []string{"tag:server"}}},
+			})
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testDeviceTagsWaitForDevice,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tailscale_device_tags.test_tags", "id", "device1CNTRL"),
+					resource.TestCheckTypeSetElemAttr("tailscale_device_tags.test_tags", "tags.*", "tag:server"),
+				),
+			},
+		},
+	})
+}
+
+func TestProvider_TailscaleDeviceTags_InvalidWaitForDevice(t *testing.T) {
+	runExpectedErrorTests(t, []expectedErrorTestCase{
+		{
+			Name: "wait-for-device-1s",
+			Config: `
+				resource "tailscale_device_tags" "test_tags" {
+					device_id       = "device1CNTRL"
+					tags            = ["tag:server"]
+					wait_for_device = "1s"
+				}`,
+			ExpectError: regexp.MustCompile(`Attribute wait_for_device duration must be greater than 1 second, got: 1s`),
+		},
+	})
+}
+
 func TestAccTailscaleDeviceTags(t *testing.T) {
 	const resourceName = "tailscale_device_tags.test_tags"
 
@@ -0,0 +1,254 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"tailscale.com/client/tailscale/v2"
+)
+
+const resourceExitNodeDescription = `The exit_node resource allows you to approve a device as an exit node. See https://tailscale.com/kb/1103/exit-nodes for more information.
+
+This resource enables both of the exit node routes (` + "`0.0.0.0/0` and `::/0`" + `) for the device, and disables them again when destroyed. Any other subnet routes enabled for the device are left untouched. The device must already be advertising itself as an exit node.
+
+Note: this resource should not be used together with a tailscale_device_subnet_routes resource in authoritative mode for the same device, as that resource would disable the exit node routes. Use the additive mode of tailscale_device_subnet_routes instead.
+`
+
+var (
+	_ resource.Resource                = &exitNodeResource{}
+	_ resource.ResourceWithConfigure   = &exitNodeResource{}
+	_ resource.ResourceWithImportState = &exitNodeResource{}
+	_ resource.ResourceWithModifyPlan  = &exitNodeResource{}
+)
+
+type exitNodeResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	DeviceID types.String `tfsdk:"device_id"`
+}
+
+// NewExitNodeResource returns a new exit node resource.
+func NewExitNodeResource() resource.Resource {
+	return &exitNodeResource{}
+}
+
+type exitNodeResource struct {
+	ResourceBase
+	ResourceImportedByID
+}
+
+func (r exitNodeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_exit_node"
+}
+
+func (r exitNodeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: resourceExitNodeDescription,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"device_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The device to approve as an exit node",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r exitNodeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan exitNodeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deviceID := plan.DeviceID.ValueString()
+
+	deviceRoutes, err := r.Client.Devices().SubnetRoutes(ctx, deviceID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to fetch device subnet routes",
+			"Failed to fetch subnet routes for device with ID "+deviceID+": "+err.Error(),
+		)
+		return
+	}
+
+	if !isExitNode(deviceRoutes.Advertised) {
+		resp.Diagnostics.AddError(
+			"Device is not advertising itself as an exit node",
+			exitNodeNotAdvertisedDetail(deviceID, deviceRoutes.Advertised),
+		)
+		return
+	}
+
+	routes := mergeRoutes(deviceRoutes.Enabled, nil, exitNodeRoutes)
+	if err := r.Client.Devices().SetSubnetRoutes(ctx, deviceID, routes); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to update device subnet routes",
+			"Failed to update subnet routes for device with ID "+deviceID+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(deviceID)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r exitNodeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state exitNodeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deviceID := state.ID.ValueString()
+
+	deviceRoutes, err := r.Client.Devices().SubnetRoutes(ctx, deviceID)
+	if err != nil {
+		// If the device is not found, remove from the state so we can create it again.
+		if tailscale.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Failed to fetch device subnet routes",
+			"Failed to fetch subnet routes for device with ID "+deviceID+": "+err.Error(),
+		)
+		return
+	}
+
+	// If the exit node routes were disabled outside of Terraform, remove from
+	// the state so that they are enabled again.
+	if !isExitNode(deviceRoutes.Enabled) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.DeviceID = types.StringValue(deviceID)
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r exitNodeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// The device_id requires replacement, so there is nothing to update.
+	var plan exitNodeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r exitNodeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state exitNodeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deviceID := state.DeviceID.ValueString()
+
+	deviceRoutes, err := r.Client.Devices().SubnetRoutes(ctx, deviceID)
+	if tailscale.IsNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to fetch device subnet routes",
+			"Failed to fetch subnet routes for device with ID "+deviceID+": "+err.Error(),
+		)
+		return
+	}
+
+	routes := mergeRoutes(deviceRoutes.Enabled, exitNodeRoutes, nil)
+	if err := r.Client.Devices().SetSubnetRoutes(ctx, deviceID, routes); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to update device subnet routes",
+			"Failed to update subnet routes for device with ID "+deviceID+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r exitNodeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// If the entire plan is null, the resource is planned for destruction.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan exitNodeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The device may not exist yet, in which case it is checked when the
+	// resource is created.
+	if plan.DeviceID.IsUnknown() {
+		return
+	}
+
+	deviceID := plan.DeviceID.ValueString()
+	deviceRoutes, err := r.Client.Devices().SubnetRoutes(ctx, deviceID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to fetch device subnet routes",
+			"Failed to fetch subnet routes for device with ID "+deviceID+": "+err.Error(),
+		)
+		return
+	}
+
+	if isExitNode(deviceRoutes.Advertised) {
+		return
+	}
+
+	summary := "Device is not advertising itself as an exit node"
+	detail := exitNodeNotAdvertisedDetail(deviceID, deviceRoutes.Advertised)
+
+	// A device that was already approved may later stop advertising the exit
+	// node routes, which should not block plans for other resources.
+	if !req.State.Raw.IsNull() {
+		var state exitNodeResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.DeviceID.Equal(plan.DeviceID) {
+			resp.Diagnostics.AddAttributeWarning(path.Root("device_id"), summary, detail)
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(path.Root("device_id"), summary, detail)
+}
+
+// exitNodeNotAdvertisedDetail describes why a device with the given advertised
+// routes cannot be approved as an exit node.
+func exitNodeNotAdvertisedDetail(deviceID string, advertised []string) string {
+	return "The device with ID " + deviceID + " is not advertising the exit node routes " +
+		strings.Join(missingElements(exitNodeRoutes, advertised), ", ") +
+		". Run `tailscale set --advertise-exit-node` on the device first."
+}
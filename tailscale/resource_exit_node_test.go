@@ -0,0 +1,121 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"tailscale.com/client/tailscale/v2"
+)
+
+const testExitNode = `
+	resource "tailscale_exit_node" "test_exit_node" {
+		device_id = "node-123"
+	}`
+
+func TestProvider_ExitNode(t *testing.T) {
+	advertised := []string{"10.0.0.0/24", "0.0.0.0/0", "::/0"}
+	enabled := []string{"10.0.0.0/24"}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			// The device starts out with only a subnet route enabled. Each
+			// request to set the routes toggles the exit node routes.
+			testServer.HandleRequest = func(method, path string) TestResponse {
+				if method == http.MethodPost {
+					if isExitNode(enabled) {
+						enabled = []string{"10.0.0.0/24"}
+					} else {
+						enabled = []string{"0.0.0.0/0", "10.0.0.0/24", "::/0"}
+					}
+					return TestResponse{Code: http.StatusOK, Body: map[string]any{}}
+				}
+				return TestResponse{Code: http.StatusOK, Body: tailscale.DeviceRoutes{Advertised: advertised, Enabled: enabled}}
+			}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		CheckDestroy: func(_ *terraform.State) error {
+			var body map[string][]string
+			if err := json.Unmarshal(testServer.Body.Bytes(), &body); err != nil {
+				return err
+			}
+			if want := []string{"10.0.0.0/24"}; !reflect.DeepEqual(body["routes"], want) {
+				return fmt.Errorf("want routes %v to remain enabled on destroy, got %v", want, body["routes"])
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testExitNode,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tailscale_exit_node.test_exit_node", "id", "node-123"),
+					resource.TestCheckResourceAttr("tailscale_exit_node.test_exit_node", "device_id", "node-123"),
+				),
+			},
+		},
+	})
+}
+
+func TestProvider_ExitNode_NoLongerAdvertised(t *testing.T) {
+	advertised := []string{"0.0.0.0/0", "::/0"}
+	enabled := []string{}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.HandleRequest = func(method, path string) TestResponse {
+				if method == http.MethodPost {
+					var body map[string][]string
+					if err := json.Unmarshal(testServer.Body.Bytes(), &body); err != nil {
+						return TestResponse{Code: http.StatusBadRequest, Body: map[string]any{"message": err.Error()}}
+					}
+					enabled = body["routes"]
+					return TestResponse{Code: http.StatusOK, Body: map[string]any{}}
+				}
+				return TestResponse{Code: http.StatusOK, Body: tailscale.DeviceRoutes{Advertised: advertised, Enabled: enabled}}
+			}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testExitNode,
+			},
+			{
+				// The device stops advertising the routes after it was
+				// approved, which only warns.
+				PreConfig: func() {
+					advertised = []string{}
+				},
+				Config:   testExitNode,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestProvider_ExitNode_NotAdvertised(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.SetResponses([]TestResponse{
+				{Code: http.StatusOK, Body: tailscale.DeviceRoutes{Advertised: []string{"10.0.0.0/24", "0.0.0.0/0"}}},
+			})
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config:      testExitNode,
+				ExpectError: regexp.MustCompile(`not\s+advertising\s+the\s+exit\s+node\s+routes\s+::/0`),
+			},
+		},
+	})
+}
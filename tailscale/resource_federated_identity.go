@@ -5,7 +5,9 @@ package tailscale
 
 import (
 	"context"
+	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -26,9 +28,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &federatedIdentityResource{}
-	_ resource.ResourceWithConfigure   = &federatedIdentityResource{}
-	_ resource.ResourceWithImportState = &federatedIdentityResource{}
+	_ resource.Resource                   = &federatedIdentityResource{}
+	_ resource.ResourceWithConfigure      = &federatedIdentityResource{}
+	_ resource.ResourceWithImportState    = &federatedIdentityResource{}
+	_ resource.ResourceWithValidateConfig = &federatedIdentityResource{}
+	_ resource.ResourceWithModifyPlan     = &federatedIdentityResource{}
 )
 
 // NewFederatedIdentityResource returns a new federated identity resource.
@@ -90,18 +94,24 @@ func (r *federatedIdentityResource) Schema(_ context.Context, _ resource.SchemaR
 				},
 			},
 			"subject": schema.StringAttribute{
-				Description: "The pattern used when matching against the `sub` claim from an OIDC identity token. Patterns can include `*` characters to match against any character.",
-				Required:    true,
+				Description: "The pattern used when matching against the `sub` claim from an OIDC identity token. Patterns can include `*` characters to match against any character. Required unless `preset` is set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("subject"), path.MatchRoot("preset")),
+				},
 			},
 			"issuer": schema.StringAttribute{
-				Description: "The issuer of the OIDC identity token used in the token exchange. Must be a valid and publicly reachable https:// URL.",
-				Required:    true,
+				Description: "The issuer of the OIDC identity token used in the token exchange. Must be a valid and publicly reachable https:// URL. Required unless `preset` is set.",
+				Optional:    true,
+				Computed:    true,
 				Validators: []validator.String{
 					httpsURLValidator{},
+					stringvalidator.ExactlyOneOf(path.MatchRoot("issuer"), path.MatchRoot("preset")),
 				},
 			},
 			"custom_claim_rules": schema.MapAttribute{
-				Description: "A map of claim names to pattern strings used to match against arbitrary claims in the OIDC identity token. Patterns can include `*` characters to match against any character.",
+				Description: "A map of claim names to pattern strings used to match against arbitrary claims in the OIDC identity token. Patterns can include `*` characters to match against any character. If `preset` is set, these are added to the claim rules of the preset.",
 				Optional:    true,
 				Computed:    true,
 				ElementType: types.StringType,
@@ -110,6 +120,15 @@ func (r *federatedIdentityResource) Schema(_ context.Context, _ resource.SchemaR
 				},
 				Default: mapdefault.StaticValue(types.MapValueMust(types.StringType, map[string]attr.Value{})),
 			},
+			"validate_issuer": schema.StringAttribute{
+				Description: "Whether to check at plan time that the issuer serves an OpenID Connect discovery document for exactly the configured issuer, and that its JSON Web Key Set can be fetched and contains signing keys. The issuer is checked when the federated identity is created and whenever the issuer changes. Valid values are `error`, which fails the plan if the check fails, `warn` and `none`. Defaults to `warn`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("warn"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("error", "warn", "none"),
+				},
+			},
 			"created_at": schema.StringAttribute{
 				Description: "The creation timestamp of the key in RFC3339 format",
 				Computed:    true,
@@ -129,10 +148,15 @@ func (r *federatedIdentityResource) Schema(_ context.Context, _ resource.SchemaR
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"preset": federatedIdentityPresetBlock(),
+		},
 	}
 }
 
-type federatedIdentityResourceModel struct {
+// federatedIdentityModel holds the federated identity metadata shared by the
+// federated_identity resource and the federated_identities data source.
+type federatedIdentityModel struct {
 	ID               types.String `tfsdk:"id"`
 	Description      types.String `tfsdk:"description"`
 	Scopes           types.Set    `tfsdk:"scopes"`
@@ -146,6 +170,12 @@ type federatedIdentityResourceModel struct {
 	UserID           types.String `tfsdk:"user_id"`
 }
 
+type federatedIdentityResourceModel struct {
+	federatedIdentityModel
+	Preset         *federatedIdentityPresetModel `tfsdk:"preset"`
+	ValidateIssuer types.String                  `tfsdk:"validate_issuer"`
+}
+
 // Create creates a new federated identity.
 func (r *federatedIdentityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data federatedIdentityResourceModel
@@ -175,7 +205,7 @@ func (r *federatedIdentityResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
-	resp.Diagnostics.Append(r.populateFromKey(ctx, &data, key)...)
+	resp.Diagnostics.Append(data.populateFromKey(ctx, key)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -200,10 +230,14 @@ func (r *federatedIdentityResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
-	resp.Diagnostics.Append(r.populateFromKey(ctx, &data, key)...)
+	resp.Diagnostics.Append(data.populateFromKey(ctx, key)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	// validate_issuer is not set when the federated identity is imported.
+	if data.ValidateIssuer.IsNull() {
+		data.ValidateIssuer = types.StringValue("warn")
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -236,7 +270,7 @@ func (r *federatedIdentityResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	resp.Diagnostics.Append(r.populateFromKey(ctx, &data, key)...)
+	resp.Diagnostics.Append(data.populateFromKey(ctx, key)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -257,13 +291,129 @@ func (r *federatedIdentityResource) Delete(ctx context.Context, req resource.Del
 	}
 }
 
+// ValidateConfig checks that exactly one preset is set in the preset block.
+func (r *federatedIdentityResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data federatedIdentityResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Preset == nil {
+		return
+	}
+
+	if data.Preset.count() != 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("preset"),
+			"Invalid preset",
+			"Exactly one of github_actions, gitlab_ci, terraform_cloud, buildkite, kubernetes or gcp must be set in the preset block.",
+		)
+	}
+}
+
+// ModifyPlan computes the issuer, subject and custom claim rules from the
+// preset, if set, warns about issuers and subjects that are unsafe, and
+// validates new issuers.
+func (r *federatedIdentityResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the federated identity is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan federatedIdentityResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Preset != nil {
+		var configuredRules types.Map
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("custom_claim_rules"), &configuredRules)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		match, ok := plan.Preset.resolve()
+		if !ok || configuredRules.IsUnknown() {
+			plan.Issuer = types.StringUnknown()
+			plan.Subject = types.StringUnknown()
+			plan.CustomClaimRules = types.MapUnknown(types.StringType)
+		} else {
+			var rules map[string]string
+			resp.Diagnostics.Append(configuredRules.ElementsAs(ctx, &rules, false)...)
+			match = match.withCustomClaimRules(rules)
+
+			plan.Issuer = types.StringValue(match.Issuer)
+			plan.Subject = types.StringValue(match.Subject)
+			var diags diag.Diagnostics
+			plan.CustomClaimRules, diags = types.MapValueFrom(ctx, types.StringType, match.CustomClaimRules)
+			resp.Diagnostics.Append(diags...)
+		}
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	r.planMatchWarnings(ctx, req, resp, &plan)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.planIssuerValidation(ctx, req, resp, &plan)
+}
+
+// planMatchWarnings warns about unsafe issuers and subjects when they are set
+// for the first time or change.
+func (r *federatedIdentityResource) planMatchWarnings(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, plan *federatedIdentityResourceModel) {
+	if plan.Issuer.IsUnknown() || plan.Subject.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var stateIssuer, stateSubject types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("issuer"), &stateIssuer)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("subject"), &stateSubject)...)
+		if resp.Diagnostics.HasError() || stateIssuer.Equal(plan.Issuer) && stateSubject.Equal(plan.Subject) {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(federatedIdentityMatchWarnings(plan.Issuer.ValueString(), plan.Subject.ValueString())...)
+}
+
+// planIssuerValidation checks the OIDC discovery document and signing keys of
+// the issuer when it is set for the first time or changes, reporting failures
+// as warnings or errors according to validate_issuer.
+func (r *federatedIdentityResource) planIssuerValidation(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, plan *federatedIdentityResourceModel) {
+	if plan.Issuer.IsUnknown() || plan.ValidateIssuer.IsUnknown() || plan.ValidateIssuer.ValueString() == "none" {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var stateIssuer types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("issuer"), &stateIssuer)...)
+		if resp.Diagnostics.HasError() || stateIssuer.Equal(plan.Issuer) {
+			return
+		}
+	}
+
+	issuer := plan.Issuer.ValueString()
+	if err := validateOIDCIssuer(ctx, oidcHTTPClient, issuer); err != nil {
+		summary := "Failed to validate issuer"
+		detail := fmt.Sprintf("Identity tokens from %s may not be accepted: %s. Set validate_issuer to \"none\" to skip this check.", issuer, err)
+		if plan.ValidateIssuer.ValueString() == "error" {
+			resp.Diagnostics.AddAttributeError(path.Root("issuer"), summary, detail)
+		} else {
+			resp.Diagnostics.AddAttributeWarning(path.Root("issuer"), summary, detail)
+		}
+	}
+}
+
 // ImportState implements state passthrough for import.
 func (r *federatedIdentityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
 // populateFromKey updates the model with data from the API key response.
-func (r *federatedIdentityResource) populateFromKey(ctx context.Context, data *federatedIdentityResourceModel, key *tailscale.Key) diag.Diagnostics {
+func (data *federatedIdentityModel) populateFromKey(ctx context.Context, key *tailscale.Key) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	data.ID = types.StringValue(key.ID)
@@ -291,6 +441,31 @@ func (r *federatedIdentityResource) populateFromKey(ctx context.Context, data *f
 	return diags
 }
 
+// federatedIdentityMatchWarnings warns about subjects that match any identity
+// token and issuers that are not HTTPS URLs, which let more workloads than
+// intended exchange identity tokens for access tokens.
+func federatedIdentityMatchWarnings(issuer, subject string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if strings.Trim(subject, "*") == "" {
+		diags.AddAttributeWarning(
+			path.Root("subject"),
+			"Subject matches any identity token",
+			fmt.Sprintf("The subject %q matches every identity token issued by %s, so any workload that can obtain a token from the issuer can use this federated identity. Restrict the subject to the workloads that should have access.", subject, issuer),
+		)
+	}
+
+	if u, err := url.Parse(issuer); err != nil || u.Scheme != "https" {
+		diags.AddAttributeWarning(
+			path.Root("issuer"),
+			"Issuer is not an HTTPS URL",
+			fmt.Sprintf("The issuer %q is not an https:// URL. Identity tokens can only be verified for issuers whose discovery document and signing keys are served over HTTPS.", issuer),
+		)
+	}
+
+	return diags
+}
+
 // reservedClaimKeysValidator rejects "sub" and "iss" as custom_claim_rules keys,
 // since those claims are matched via the dedicated subject and issuer fields.
 type reservedClaimKeysValidator struct{}
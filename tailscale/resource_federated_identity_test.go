@@ -28,6 +28,8 @@ func TestProvider_TailscaleFederatedIdentity(t *testing.T) {
         custom_claim_rules = {
             repo_name = "example-repo-name"
         }
+
+        validate_issuer = "none"
 	}`
 
 	resource.Test(t, resource.TestCase{
@@ -77,6 +79,8 @@ func TestProvider_TailscaleFederatedIdentity_ReservedCustomClaimKeys(t *testing.
 				custom_claim_rules = {
 					%s = "some-value"
 				}
+
+				validate_issuer = "none"
 			}`, reservedKey)
 
 			resource.Test(t, resource.TestCase{
@@ -93,6 +97,155 @@ func TestProvider_TailscaleFederatedIdentity_ReservedCustomClaimKeys(t *testing.
 	}
 }
 
+func TestProvider_TailscaleFederatedIdentity_Preset(t *testing.T) {
+	const resourceName = "tailscale_federated_identity.github"
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.ResponseCode = http.StatusOK
+			testServer.ResponseBody = tailscale.Key{
+				ID:      "github",
+				Scopes:  []string{"policy_file"},
+				Issuer:  "https://token.actions.githubusercontent.com",
+				Subject: "repo:example/acls:environment:production",
+				CustomClaimRules: map[string]string{
+					"ref":   "refs/heads/main",
+					"actor": "octocat",
+				},
+			}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "tailscale_federated_identity" "github" {
+						scopes = ["policy_file"]
+						custom_claim_rules = {
+							actor = "octocat"
+						}
+
+						validate_issuer = "none"
+
+						preset {
+							github_actions {
+								repository  = "example/acls"
+								ref         = "refs/heads/main"
+								environment = "production"
+							}
+						}
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "issuer", "https://token.actions.githubusercontent.com"),
+					resource.TestCheckResourceAttr(resourceName, "subject", "repo:example/acls:environment:production"),
+					resource.TestCheckResourceAttr(resourceName, "custom_claim_rules.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "custom_claim_rules.ref", "refs/heads/main"),
+					resource.TestCheckResourceAttr(resourceName, "custom_claim_rules.actor", "octocat"),
+				),
+			},
+		},
+	})
+}
+
+func TestProvider_TailscaleFederatedIdentity_InvalidPreset(t *testing.T) {
+	testCases := []expectedErrorTestCase{
+		{
+			Name: "preset-and-issuer",
+			Config: `resource "tailscale_federated_identity" "test" {
+				scopes = ["auth_keys"]
+				issuer = "https://token.actions.githubusercontent.com"
+
+				preset {
+					github_actions {
+						repository = "example/acls"
+					}
+				}
+			}`,
+			ExpectError: regexp.MustCompile(`Invalid\s+Attribute\s+Combination`),
+		},
+		{
+			Name: "no-preset-or-subject",
+			Config: `resource "tailscale_federated_identity" "test" {
+				scopes = ["auth_keys"]
+				issuer = "https://token.actions.githubusercontent.com"
+			}`,
+			ExpectError: regexp.MustCompile(`Invalid\s+Attribute\s+Combination`),
+		},
+		{
+			Name: "empty-preset",
+			Config: `resource "tailscale_federated_identity" "test" {
+				scopes = ["auth_keys"]
+				preset {}
+			}`,
+			ExpectError: regexp.MustCompile(`Invalid\s+preset`),
+		},
+		{
+			Name: "multiple-presets",
+			Config: `resource "tailscale_federated_identity" "test" {
+				scopes = ["auth_keys"]
+
+				preset {
+					github_actions {
+						repository = "example/acls"
+					}
+					buildkite {
+						organization = "example"
+						pipeline     = "acls"
+					}
+				}
+			}`,
+			ExpectError: regexp.MustCompile(`Invalid\s+preset`),
+		},
+	}
+
+	runExpectedErrorTests(t, testCases)
+}
+
+func TestProvider_TailscaleFederatedIdentity_ValidateIssuer(t *testing.T) {
+	issuer := newTestOIDCIssuer(t)
+
+	httpClient := oidcHTTPClient
+	oidcHTTPClient = issuer.Client()
+	t.Cleanup(func() { oidcHTTPClient = httpClient })
+
+	config := fmt.Sprintf(`
+		resource "tailscale_federated_identity" "test" {
+			scopes          = ["auth_keys"]
+			issuer          = %q
+			subject         = "system:serviceaccount:tailscale:operator"
+			validate_issuer = "error"
+		}`, issuer.URL)
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				// The discovery document is for a different issuer.
+				PreConfig: func() {
+					issuer.Issuer = issuer.URL + "/"
+				},
+				Config:      config,
+				ExpectError: regexp.MustCompile(`Failed\s+to\s+validate\s+issuer`),
+			},
+			{
+				PreConfig: func() {
+					issuer.Issuer = ""
+					testServer.ResponseCode = http.StatusOK
+					testServer.ResponseBody = tailscale.Key{
+						ID:      "test",
+						Scopes:  []string{"auth_keys"},
+						Issuer:  issuer.URL,
+						Subject: "system:serviceaccount:tailscale:operator",
+					}
+				},
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("tailscale_federated_identity.test", "issuer", issuer.URL),
+			},
+		},
+	})
+}
+
 func TestAccTailscaleFederatedIdentity(t *testing.T) {
 	const resourceName = "tailscale_federated_identity.test_federated_identity"
 
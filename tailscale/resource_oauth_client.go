@@ -9,6 +9,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -24,19 +26,41 @@ var (
 	_ resource.Resource                = &oauthClientResource{}
 	_ resource.ResourceWithConfigure   = &oauthClientResource{}
 	_ resource.ResourceWithImportState = &oauthClientResource{}
+	_ resource.ResourceWithModifyPlan  = &oauthClientResource{}
 )
 
-type oauthClientResourceModel struct {
+// oauthClientModel holds the OAuth client metadata shared by the oauth_client
+// resource and the oauth_clients data source.
+type oauthClientModel struct {
 	ID          types.String `tfsdk:"id"`
 	Description types.String `tfsdk:"description"`
 	Scopes      types.Set    `tfsdk:"scopes"`
 	Tags        types.Set    `tfsdk:"tags"`
-	Key         types.String `tfsdk:"key"`
 	CreatedAt   types.String `tfsdk:"created_at"`
 	UpdatedAt   types.String `tfsdk:"updated_at"`
 	UserID      types.String `tfsdk:"user_id"`
 }
 
+// readFrom sets the OAuth client metadata from the key returned by the API.
+func (m *oauthClientModel) readFrom(ctx context.Context, key *tailscale.Key) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.StringValue(key.ID)
+	m.Description = types.StringValue(key.Description)
+	m.CreatedAt = types.StringValue(key.Created.Format(time.RFC3339))
+	m.UpdatedAt = types.StringValue(key.Updated.Format(time.RFC3339))
+	m.UserID = types.StringValue(key.UserID)
+	m.Scopes = SetOfStringValue(ctx, nonNil(key.Scopes), &diags)
+	m.Tags = SetOfStringValue(ctx, nonNil(key.Tags), &diags)
+	return diags
+}
+
+type oauthClientResourceModel struct {
+	oauthClientModel
+	Key             types.String `tfsdk:"key"`
+	RotationTrigger types.String `tfsdk:"rotation_trigger"`
+}
+
 func NewOAuthClientResource() resource.Resource {
 	return &oauthClientResource{}
 }
@@ -108,6 +132,10 @@ func (r *oauthClientResource) Schema(_ context.Context, _ resource.SchemaRequest
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"rotation_trigger": schema.StringAttribute{
+				Optional:    true,
+				Description: "An arbitrary value, such as a timestamp, that causes the client secret to be rotated whenever it changes. The API does not support regenerating the secret of an existing OAuth client, so the client is replaced and its ID changes as well. Setting this for the first time or removing it does not rotate the secret.",
+			},
 		},
 	}
 }
@@ -129,16 +157,10 @@ func (r *oauthClientResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	state.Description = types.StringValue(key.Description)
-	state.CreatedAt = types.StringValue(key.Created.Format(time.RFC3339))
-	state.UpdatedAt = types.StringValue(key.Updated.Format(time.RFC3339))
-	state.UserID = types.StringValue(key.UserID)
-	state.Scopes = SetOfStringValue(ctx, key.Scopes, &resp.Diagnostics)
-
-	if key.Tags == nil {
-		key.Tags = []string{}
+	resp.Diagnostics.Append(state.readFrom(ctx, key)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	state.Tags = SetOfStringValue(ctx, key.Tags, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -199,6 +221,36 @@ func (r *oauthClientResource) Update(ctx context.Context, req resource.UpdateReq
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
+func (r *oauthClientResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to rotate when the client is being created or destroyed.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state oauthClientResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.RotationTrigger.IsNull() || state.RotationTrigger.IsNull() {
+		return
+	}
+	// An unknown trigger is treated as a change, as it is only unknown when
+	// the value it is derived from changes, e.g. when a time_rotating rotates.
+	if !plan.RotationTrigger.IsUnknown() && plan.RotationTrigger.Equal(state.RotationTrigger) {
+		return
+	}
+
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("rotation_trigger"))
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("rotation_trigger"),
+		"OAuth client will be replaced to rotate its secret",
+		"The Tailscale API does not support regenerating the secret of an existing OAuth client, so the client "+state.ID.ValueString()+" will be deleted and a new client created with a new ID and secret. Anything that references the client ID must be updated to use the new client.",
+	)
+}
+
 func (r *oauthClientResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state oauthClientResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
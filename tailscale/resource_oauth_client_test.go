@@ -13,7 +13,9 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"tailscale.com/client/tailscale/v2"
 )
 
@@ -42,6 +44,96 @@ func TestProvider_TailscaleOAuthClient(t *testing.T) {
 	})
 }
 
+func TestProvider_TailscaleOAuthClientRotation(t *testing.T) {
+	const resourceName = "tailscale_oauth_client.example_oauth_client"
+
+	oauthClient := func(id, key string) tailscale.Key {
+		return tailscale.Key{
+			ID:      id,
+			Key:     key,
+			KeyType: "client",
+			Scopes:  []string{"auth_keys"},
+			Tags:    []string{"tag:test"},
+		}
+	}
+
+	clientConfig := func(rotationTrigger string) string {
+		return fmt.Sprintf(`
+			resource "tailscale_oauth_client" "example_oauth_client" {
+				scopes           = ["auth_keys"]
+				tags             = ["tag:test"]
+				rotation_trigger = %s
+			}`, rotationTrigger)
+	}
+
+	checkClient := func(id, key string) resource.TestCheckFunc {
+		return resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "id", id),
+			resource.TestCheckResourceAttr(resourceName, "key", key),
+		)
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					testServer.SetResponses([]TestResponse{
+						{Code: http.StatusOK, Body: oauthClient("client1", "secret1")},
+					})
+				},
+				Config: clientConfig("null"),
+				Check:  checkClient("client1", "secret1"),
+			},
+			{
+				// Setting the trigger for the first time doesn't rotate the secret.
+				Config: clientConfig(`"2026-01"`),
+				Check:  checkClient("client1", "secret1"),
+			},
+			{
+				// Changing the trigger replaces the client.
+				PreConfig: func() {
+					testServer.SetResponses([]TestResponse{
+						{Code: http.StatusOK, Body: oauthClient("client1", "")},
+						{Code: http.StatusOK, Body: oauthClient("client2", "secret2")},
+					})
+				},
+				Config: clientConfig(`"2026-02"`),
+				Check: resource.ComposeTestCheckFunc(
+					checkClient("client2", "secret2"),
+					resource.TestCheckResourceAttr(resourceName, "rotation_trigger", "2026-02"),
+				),
+			},
+			{
+				// A trigger that is unknown when planning also replaces the
+				// client.
+				PreConfig: func() {
+					testServer.SetResponses([]TestResponse{
+						{Code: http.StatusOK, Body: oauthClient("client2", "")},
+						{Code: http.StatusOK, Body: oauthClient("client3", "secret3")},
+					})
+				},
+				Config: `
+					resource "terraform_data" "trigger" {
+						input = "2026-03"
+					}
+				` + clientConfig("terraform_data.trigger.output"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue(resourceName, tfjsonpath.New("rotation_trigger")),
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					checkClient("client3", "secret3"),
+					resource.TestCheckResourceAttr(resourceName, "rotation_trigger", "2026-03"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccTailscaleOAuthClient(t *testing.T) {
 	const resourceName = "tailscale_oauth_client.test_client"
 
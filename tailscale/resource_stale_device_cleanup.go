@@ -0,0 +1,380 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"tailscale.com/client/tailscale/v2"
+)
+
+const resourceStaleDeviceCleanupDescription = `The stale_device_cleanup resource deletes devices that have not been seen for a given number of days.
+
+Stale devices are looked up whenever the state is refreshed and recorded in the candidate_node_ids attribute. If there are any, the plan includes an update of this resource, and applying it deletes those of them that are still stale. When the resource is created or the attributes that select the devices change, the stale devices are only looked up, so that devices are never deleted before they were listed in a plan. Devices that are currently connected are never deleted, and ephemeral devices are only deleted if include_ephemeral is set. Set dry_run to only report the devices that would be deleted in the candidate_node_ids attribute.
+
+As a safety measure, planning fails rather than deleting more than max_deletions devices at once.
+`
+
+var (
+	_ resource.Resource               = &staleDeviceCleanupResource{}
+	_ resource.ResourceWithConfigure  = &staleDeviceCleanupResource{}
+	_ resource.ResourceWithModifyPlan = &staleDeviceCleanupResource{}
+)
+
+type staleDeviceCleanupResourceModel struct {
+	ID               types.String  `tfsdk:"id"`
+	LastSeenDays     types.Int64   `tfsdk:"last_seen_days"`
+	Tags             types.Set     `tfsdk:"tags"`
+	NamePrefix       types.String  `tfsdk:"name_prefix"`
+	Filters          []filterModel `tfsdk:"filter"`
+	ExcludeNodeIDs   types.Set     `tfsdk:"exclude_node_ids"`
+	IncludeEphemeral types.Bool    `tfsdk:"include_ephemeral"`
+	DryRun           types.Bool    `tfsdk:"dry_run"`
+	MaxDeletions     types.Int64   `tfsdk:"max_deletions"`
+	CandidateNodeIDs types.Set     `tfsdk:"candidate_node_ids"`
+	DeletedNodeIDs   types.Set     `tfsdk:"deleted_node_ids"`
+}
+
+// NewStaleDeviceCleanupResource returns a new stale device cleanup resource.
+func NewStaleDeviceCleanupResource() resource.Resource {
+	return &staleDeviceCleanupResource{}
+}
+
+type staleDeviceCleanupResource struct {
+	ResourceBase
+}
+
+func (r staleDeviceCleanupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_stale_device_cleanup"
+}
+
+func (r staleDeviceCleanupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: resourceStaleDeviceCleanupDescription,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_seen_days": schema.Int64Attribute{
+				Required:    true,
+				Description: "Delete devices that have not been seen for at least this many days",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"tags": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Only delete devices that have all of the given tags applied",
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only delete devices whose name has the provided prefix",
+			},
+			"exclude_node_ids": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The node IDs of devices that must never be deleted",
+			},
+			"include_ephemeral": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether to also delete stale ephemeral devices. Tailscale removes ephemeral devices automatically shortly after they go offline, so they are excluded by default. Defaults to `false`.",
+				Default:     booldefault.StaticBool(false),
+			},
+			"dry_run": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "If true, no devices are deleted and the devices that would be deleted are only reported in candidate_node_ids. Defaults to `false`.",
+				Default:     booldefault.StaticBool(false),
+			},
+			"max_deletions": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The maximum number of devices to delete in a single apply. If more devices are stale, planning fails and no devices are deleted. Defaults to `10`.",
+				Default:     int64default.StaticInt64(10),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"candidate_node_ids": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The node IDs of the stale devices that were found when the state was last refreshed. Unless in dry run mode, these are the devices that are deleted when the plan is applied.",
+			},
+			"deleted_node_ids": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The node IDs of the devices that were deleted when the resource was last applied. Devices in candidate_node_ids that are no longer stale at that point are not deleted.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": schema.SetNestedBlock{
+				Description: "Only delete devices whose fields match the provided values. Filters are passed to the API in the same way as for the tailscale_devices data source.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name must be a top-level device property, e.g. isEphemeral, tags, hostname, etc.",
+							Required:    true,
+						},
+						"values": schema.SetAttribute{
+							Description: "The list of values to filter for. Values are matched as exact matches.",
+							ElementType: types.StringType,
+							Required:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r staleDeviceCleanupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan staleDeviceCleanupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(createUUID())
+	r.cleanup(ctx, &plan, &resp.Diagnostics)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r staleDeviceCleanupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Stale devices are looked up when refreshing the state, so that the plan
+	// made again during apply deletes the same devices as the reviewed plan.
+	// Refreshing the state never deletes any devices.
+	var state staleDeviceCleanupResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	candidates := r.findStaleDevices(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.CandidateNodeIDs = SetOfStringValue(ctx, candidates, &resp.Diagnostics)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r staleDeviceCleanupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan staleDeviceCleanupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The state is set even if deleting a device failed, so that the devices
+	// deleted before the failure are still recorded.
+	r.cleanup(ctx, &plan, &resp.Diagnostics)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r staleDeviceCleanupResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	// Deleted devices cannot be restored, so there is nothing to do.
+}
+
+func (r staleDeviceCleanupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// If the entire plan is null, the resource is planned for destruction.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan staleDeviceCleanupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// When the resource is created, the stale devices are only looked up, and
+	// they are deleted by a later apply once they are known when planning.
+	if req.State.Raw.IsNull() {
+		plan.CandidateNodeIDs = types.SetUnknown(types.StringType)
+		plan.DeletedNodeIDs = SetOfStringValue(ctx, []string{}, &resp.Diagnostics)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	var state staleDeviceCleanupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The same goes when the way stale devices are looked up changes, as the
+	// candidates in the state were looked up the previous way.
+	plan.DeletedNodeIDs = state.DeletedNodeIDs
+	if !plan.isKnown(ctx) || !plan.looksUpLike(state) {
+		plan.CandidateNodeIDs = types.SetUnknown(types.StringType)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	// The candidates were looked up when refreshing the state, which is not
+	// done again when the plan is made again during apply.
+	plan.CandidateNodeIDs = state.CandidateNodeIDs
+	var candidates []string
+	resp.Diagnostics.Append(state.CandidateNodeIDs.ElementsAs(ctx, &candidates, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.DryRun.ValueBool() && len(candidates) > 0 {
+		if int64(len(candidates)) > plan.MaxDeletions.ValueInt64() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_deletions"),
+				"Too many stale devices",
+				fmt.Sprintf("Found %d stale devices, which is more than max_deletions (%d). No devices will be deleted. Narrow down the filters or increase max_deletions. Stale devices: %s",
+					len(candidates), plan.MaxDeletions.ValueInt64(), strings.Join(candidates, ", ")),
+			)
+			return
+		}
+		plan.DeletedNodeIDs = types.SetUnknown(types.StringType)
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// isKnown reports whether all of the configured attributes are known.
+func (m staleDeviceCleanupResourceModel) isKnown(ctx context.Context) bool {
+	values := []attr.Value{m.LastSeenDays, m.Tags, m.NamePrefix, m.ExcludeNodeIDs, m.IncludeEphemeral, m.DryRun, m.MaxDeletions}
+	for _, filter := range m.Filters {
+		values = append(values, filter.Name, filter.Values)
+	}
+	return !slices.ContainsFunc(values, func(value attr.Value) bool {
+		return !IsFullyKnown(ctx, value)
+	})
+}
+
+// looksUpLike reports whether stale devices are looked up in the same way as
+// for other.
+func (m staleDeviceCleanupResourceModel) looksUpLike(other staleDeviceCleanupResourceModel) bool {
+	return m.LastSeenDays.Equal(other.LastSeenDays) &&
+		m.Tags.Equal(other.Tags) &&
+		m.NamePrefix.Equal(other.NamePrefix) &&
+		m.ExcludeNodeIDs.Equal(other.ExcludeNodeIDs) &&
+		m.IncludeEphemeral.Equal(other.IncludeEphemeral) &&
+		slices.EqualFunc(m.Filters, other.Filters, func(a, b filterModel) bool {
+			return a.Name.Equal(b.Name) && a.Values.Equal(b.Values)
+		})
+}
+
+// cleanup looks up the stale devices matching the configuration if they were
+// not known when planning. Otherwise, if devices are planned to be deleted, it
+// deletes the candidate devices and records them in the deleted node IDs.
+func (r staleDeviceCleanupResource) cleanup(ctx context.Context, data *staleDeviceCleanupResourceModel, diags *diag.Diagnostics) {
+	if data.CandidateNodeIDs.IsUnknown() {
+		candidates := r.findStaleDevices(ctx, data, diags)
+		data.CandidateNodeIDs = SetOfStringValue(ctx, nonNil(candidates), diags)
+		return
+	}
+
+	if data.DeletedNodeIDs.IsUnknown() {
+		data.DeletedNodeIDs = SetOfStringValue(ctx, r.deleteCandidates(ctx, data, diags), diags)
+	}
+}
+
+// deleteCandidates deletes the candidate devices that are still stale, as
+// devices may have been seen again since the plan was made. It returns the
+// node IDs of the deleted devices, including when deleting a device fails.
+func (r staleDeviceCleanupResource) deleteCandidates(ctx context.Context, data *staleDeviceCleanupResourceModel, diags *diag.Diagnostics) []string {
+	deleted := []string{}
+
+	var candidates []string
+	diags.Append(data.CandidateNodeIDs.ElementsAs(ctx, &candidates, false)...)
+	if diags.HasError() || len(candidates) == 0 {
+		return deleted
+	}
+
+	stale := r.findStaleDevices(ctx, data, diags)
+	if diags.HasError() {
+		return deleted
+	}
+
+	for _, nodeID := range candidates {
+		if !slices.Contains(stale, nodeID) {
+			continue
+		}
+		err := r.Client.Devices().Delete(ctx, nodeID)
+		if err != nil && !tailscale.IsNotFound(err) {
+			diags.AddError(
+				"Failed to delete device",
+				"Failed to delete device with ID "+nodeID+": "+err.Error(),
+			)
+			return deleted
+		}
+		deleted = append(deleted, nodeID)
+	}
+	return deleted
+}
+
+// findStaleDevices returns the sorted node IDs of the stale devices matching
+// the configuration.
+func (r staleDeviceCleanupResource) findStaleDevices(ctx context.Context, data *staleDeviceCleanupResourceModel, diags *diag.Diagnostics) []string {
+	var tags, excluded []string
+	diags.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+	diags.Append(data.ExcludeNodeIDs.ElementsAs(ctx, &excluded, false)...)
+	opts := deviceFilterOptions(ctx, data.Filters, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	devices, err := r.Client.Devices().List(ctx, opts...)
+	if err != nil {
+		diags.AddError("Failed to fetch devices", err.Error())
+		return nil
+	}
+
+	maxAge := time.Duration(data.LastSeenDays.ValueInt64()) * 24 * time.Hour
+	return staleDevices(devices, time.Now().Add(-maxAge), deviceFilter{Tags: tags}, data.NamePrefix.ValueString(), excluded, data.IncludeEphemeral.ValueBool())
+}
+
+// staleDevices returns the sorted node IDs of the devices that were last seen
+// before the given time and match the filter and name prefix, excluding the
+// given node IDs. Devices that are connected to control are never stale, and
+// ephemeral devices are only stale if includeEphemeral is set.
+func staleDevices(devices []tailscale.Device, lastSeenBefore time.Time, filter deviceFilter, namePrefix string, excluded []string, includeEphemeral bool) []string {
+	stale := []string{}
+	for _, device := range devices {
+		if device.ConnectedToControl || device.LastSeen == nil || !device.LastSeen.Before(lastSeenBefore) {
+			continue
+		}
+		if device.IsEphemeral && !includeEphemeral {
+			continue
+		}
+		if !strings.HasPrefix(device.Name, namePrefix) || !filter.matches(&device) || slices.Contains(excluded, device.NodeID) {
+			continue
+		}
+		stale = append(stale, device.NodeID)
+	}
+	slices.Sort(stale)
+	return stale
+}
@@ -0,0 +1,233 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"tailscale.com/client/tailscale/v2"
+)
+
+func testStaleDevices() []tailscale.Device {
+	daysAgo := func(days int) *tailscale.Time {
+		return &tailscale.Time{Time: time.Now().Add(-time.Duration(days) * 24 * time.Hour)}
+	}
+
+	return []tailscale.Device{
+		{NodeID: "old-ci", Name: "ci-1.example.ts.net", Tags: []string{"tag:ci"}, LastSeen: daysAgo(60)},
+		{NodeID: "old-web", Name: "web-1.example.ts.net", LastSeen: daysAgo(45)},
+		{NodeID: "old-connected", Name: "ci-2.example.ts.net", Tags: []string{"tag:ci"}, LastSeen: daysAgo(60), ConnectedToControl: true},
+		{NodeID: "recent-ci", Name: "ci-3.example.ts.net", Tags: []string{"tag:ci"}, LastSeen: daysAgo(2)},
+		{NodeID: "never-seen", Name: "ci-4.example.ts.net", Tags: []string{"tag:ci"}},
+		{NodeID: "old-excluded", Name: "ci-5.example.ts.net", Tags: []string{"tag:ci"}, LastSeen: daysAgo(90)},
+		{NodeID: "old-ephemeral", Name: "ci-6.example.ts.net", Tags: []string{"tag:ci"}, LastSeen: daysAgo(60), IsEphemeral: true},
+	}
+}
+
+func TestStaleDevices(t *testing.T) {
+	t.Parallel()
+
+	lastSeenBefore := time.Now().Add(-30 * 24 * time.Hour)
+
+	tests := []struct {
+		name             string
+		filter           deviceFilter
+		namePrefix       string
+		excluded         []string
+		includeEphemeral bool
+		want             []string
+	}{
+		{name: "all", want: []string{"old-ci", "old-excluded", "old-web"}},
+		{name: "tags", filter: deviceFilter{Tags: []string{"tag:ci"}}, want: []string{"old-ci", "old-excluded"}},
+		{name: "name-prefix", namePrefix: "web-", want: []string{"old-web"}},
+		{name: "excluded", excluded: []string{"old-excluded"}, want: []string{"old-ci", "old-web"}},
+		{name: "include-ephemeral", filter: deviceFilter{Tags: []string{"tag:ci"}}, includeEphemeral: true, want: []string{"old-ci", "old-ephemeral", "old-excluded"}},
+		{name: "none", namePrefix: "db-", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := staleDevices(testStaleDevices(), lastSeenBefore, tt.filter, tt.namePrefix, tt.excluded, tt.includeEphemeral)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProvider_StaleDeviceCleanup(t *testing.T) {
+	var deleted []string
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.HandleRequest = func(method, path string) TestResponse {
+				if method == http.MethodDelete {
+					deleted = append(deleted, path)
+					return TestResponse{Code: http.StatusOK, Body: map[string]any{}}
+				}
+				return TestResponse{Code: http.StatusOK, Body: map[string][]tailscale.Device{"devices": testStaleDevices()}}
+			}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "tailscale_stale_device_cleanup" "ci" {
+						last_seen_days   = 30
+						tags             = ["tag:ci"]
+						exclude_node_ids = ["old-excluded"]
+						dry_run          = true
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tailscale_stale_device_cleanup.ci", "candidate_node_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr("tailscale_stale_device_cleanup.ci", "candidate_node_ids.*", "old-ci"),
+					resource.TestCheckResourceAttr("tailscale_stale_device_cleanup.ci", "deleted_node_ids.#", "0"),
+					func(_ *terraform.State) error {
+						if len(deleted) > 0 {
+							return fmt.Errorf("expected no devices to be deleted in dry run mode, got %v", deleted)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: `
+					resource "tailscale_stale_device_cleanup" "ci" {
+						last_seen_days = 30
+						max_deletions  = 1
+					}`,
+				ExpectError: regexp.MustCompile(`Found\s+3\s+stale\s+devices,\s+which\s+is\s+more\s+than\s+max_deletions\s+\(1\)`),
+			},
+		},
+	})
+}
+
+func TestProvider_StaleDeviceCleanup_Delete(t *testing.T) {
+	const cfg = `
+		resource "tailscale_stale_device_cleanup" "ci" {
+			last_seen_days   = 30
+			tags             = ["tag:ci"]
+			exclude_node_ids = ["old-excluded"]
+		}`
+
+	devices := testStaleDevices()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.HandleRequest = func(method, path string) TestResponse {
+				if method == http.MethodDelete {
+					devices = slices.DeleteFunc(devices, func(device tailscale.Device) bool {
+						return strings.HasSuffix(path, "/device/"+device.NodeID)
+					})
+					return TestResponse{Code: http.StatusOK, Body: map[string]any{}}
+				}
+				return TestResponse{Code: http.StatusOK, Body: map[string][]tailscale.Device{"devices": devices}}
+			}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				// Creating the resource only looks up the stale devices.
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tailscale_stale_device_cleanup.ci", "candidate_node_ids.#", "1"),
+					resource.TestCheckResourceAttr("tailscale_stale_device_cleanup.ci", "deleted_node_ids.#", "0"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: cfg,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue(
+							"tailscale_stale_device_cleanup.ci",
+							tfjsonpath.New("candidate_node_ids"),
+							knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("old-ci")}),
+						),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tailscale_stale_device_cleanup.ci", "deleted_node_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr("tailscale_stale_device_cleanup.ci", "deleted_node_ids.*", "old-ci"),
+					func(_ *terraform.State) error {
+						if slices.ContainsFunc(devices, func(device tailscale.Device) bool { return device.NodeID == "old-ci" }) {
+							return fmt.Errorf("expected device old-ci to be deleted")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestProvider_StaleDeviceCleanup_UnknownAttributes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.ResponseCode = http.StatusOK
+			testServer.ResponseBody = map[string][]tailscale.Device{"devices": testStaleDevices()}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "tailscale_stale_device_cleanup" "ci" {
+						last_seen_days   = 30
+						tags             = ["tag:ci"]
+						exclude_node_ids = ["old-excluded"]
+					}`,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// The stale devices are looked up again once the attributes
+				// are known, rather than failing the plan.
+				Config: `
+					resource "terraform_data" "limits" {
+						input = {
+							max_deletions = 1
+							excluded      = "old-excluded"
+						}
+					}
+
+					resource "tailscale_stale_device_cleanup" "ci" {
+						last_seen_days   = 30
+						tags             = ["tag:ci"]
+						exclude_node_ids = [terraform_data.limits.output.excluded]
+						max_deletions    = terraform_data.limits.output.max_deletions
+					}`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestProvider_StaleDeviceCleanup_InvalidConfig(t *testing.T) {
+	testCases := []expectedErrorTestCase{
+		{
+			Name: "invalid-last-seen-days",
+			Config: `
+				resource "tailscale_stale_device_cleanup" "example" {
+					last_seen_days = 0
+				}
+			`,
+			ExpectError: regexp.MustCompile(`Attribute last_seen_days value must be at least 1`),
+		},
+	}
+
+	runExpectedErrorTests(t, testCases)
+}
@@ -35,19 +35,13 @@ var (
 )
 
 type tailnetKeyResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Reusable          types.Bool   `tfsdk:"reusable"`
-	Ephemeral         types.Bool   `tfsdk:"ephemeral"`
-	Tags              types.Set    `tfsdk:"tags"`
-	Preauthorized     types.Bool   `tfsdk:"preauthorized"`
+	tailnetKeyModel
 	Key               types.String `tfsdk:"key"`
-	Expiry            types.Int64  `tfsdk:"expiry"`
-	CreatedAt         types.String `tfsdk:"created_at"`
-	ExpiresAt         types.String `tfsdk:"expires_at"`
-	Description       types.String `tfsdk:"description"`
-	Invalid           types.Bool   `tfsdk:"invalid"`
 	RecreateIfInvalid types.String `tfsdk:"recreate_if_invalid"`
-	UserID            types.String `tfsdk:"user_id"`
+	ExpiryDuration    types.String `tfsdk:"expiry_duration"`
+	ExpiresAtTarget   types.String `tfsdk:"expires_at_target"`
+	RotateBefore      types.String `tfsdk:"rotate_before"`
+	Keepers           types.Map    `tfsdk:"keepers"`
 }
 
 func NewTailnetKeyResource() resource.Resource {
@@ -107,10 +101,31 @@ func (t *tailnetKeyResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
 			"expiry": schema.Int64Attribute{
-				Optional:      true,
-				Computed:      true,
-				Description:   "The expiry of the key in seconds. Defaults to `7776000` (90 days).",
-				PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace(), int64planmodifier.UseStateForUnknown()},
+				Optional:    true,
+				Computed:    true,
+				Description: "The expiry of the key in seconds. Defaults to `7776000` (90 days). Computed from `expiry_duration` or `expires_at_target` if one of them is set.",
+				// The state is used before checking for replacement, so that
+				// switching to expiry_duration or expires_at_target does not
+				// replace the key unless the expiry changes.
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown(), int64planmodifier.RequiresReplace()},
+			},
+			"expiry_duration": schema.StringAttribute{
+				Optional:    true,
+				Description: "The expiry of the key as a duration, e.g. `90d` or `12h`. At most 90 days. Conflicts with `expiry` and `expires_at_target`.",
+				Validators: []validator.String{
+					durationValidator{},
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("expiry"),
+						path.MatchRoot("expires_at_target"),
+					),
+				},
+			},
+			"expires_at_target": schema.StringAttribute{
+				Optional:    true,
+				Description: "The time at which the key should expire, in RFC3339 format. The expiry is computed when the key is created, and must be at most 90 days in the future. Changing this value replaces the key. Conflicts with `expiry` and `expiry_duration`.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("expiry")),
+				},
 			},
 			"created_at": schema.StringAttribute{
 				Description:   "The creation timestamp of the key in RFC3339 format",
@@ -154,6 +169,18 @@ func (t *tailnetKeyResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Description:   "ID of the user who created this key, empty for keys created by OAuth clients.",
 				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
+			"rotate_before": schema.StringAttribute{
+				Optional:    true,
+				Description: "Replace the key with a new one once it expires within this duration, e.g. `168h` or `7d`. The key is replaced by the first plan after that point in time, so keys are rotated before they expire if Terraform runs regularly. Must be shorter than the expiry of the key. Use the `create_before_destroy` lifecycle setting so that the new key exists before the old one is deleted.",
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"keepers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Arbitrary values that cause the key to be replaced whenever they change, e.g. the ID of an image or launch template.",
+			},
 		},
 	}
 }
@@ -179,6 +206,14 @@ func (t *tailnetKeyResource) Create(ctx context.Context, req resource.CreateRequ
 	createKeyRequest.Capabilities.Devices.Create.Tags = tags
 	createKeyRequest.Capabilities.Devices.Create.Preauthorized = plan.Preauthorized.ValueBool()
 	createKeyRequest.ExpirySeconds = plan.Expiry.ValueInt64()
+	if !plan.ExpiresAtTarget.IsNull() {
+		expiry, err := keyExpirySeconds("", plan.ExpiresAtTarget.ValueString(), time.Now())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("expires_at_target"), "Invalid key expiry", err.Error())
+			return
+		}
+		createKeyRequest.ExpirySeconds = expiry
+	}
 	createKeyRequest.Description = plan.Description.ValueString()
 
 	key, err := t.Client.Keys().CreateAuthKey(ctx, createKeyRequest)
@@ -244,8 +279,6 @@ func (t *tailnetKeyResource) Read(ctx context.Context, req resource.ReadRequest,
 	} else if err != nil {
 		resp.Diagnostics.AddError("Failed to fetch key", fmt.Sprintf("Error reading tailnet key with id %q: %s", state.ID, err.Error()))
 		return
-	} else {
-		state.Invalid = types.BoolValue(false)
 	}
 
 	if key.KeyType != "auth" {
@@ -253,28 +286,10 @@ func (t *tailnetKeyResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	if key.Invalid {
-		state.Invalid = types.BoolValue(true)
-	}
-
-	state.ID = types.StringValue(key.ID)
-	state.Reusable = types.BoolValue(key.Capabilities.Devices.Create.Reusable)
-	state.Ephemeral = types.BoolValue(key.Capabilities.Devices.Create.Ephemeral)
-	if key.Capabilities.Devices.Create.Tags == nil {
-		key.Capabilities.Devices.Create.Tags = []string{}
-	}
-	tags, diags := types.SetValueFrom(ctx, types.StringType, key.Capabilities.Devices.Create.Tags)
-	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(state.readFrom(ctx, key)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	state.Tags = tags
-	state.Preauthorized = types.BoolValue(key.Capabilities.Devices.Create.Preauthorized)
-	state.Expiry = types.Int64PointerValue((*int64)(key.ExpirySeconds))
-	state.CreatedAt = types.StringValue(key.Created.Format(time.RFC3339))
-	state.ExpiresAt = types.StringValue(key.Expires.Format(time.RFC3339))
-	state.Description = types.StringValue(key.Description)
-	state.UserID = types.StringValue(key.UserID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
@@ -315,30 +330,168 @@ func (t *tailnetKeyResource) Update(ctx context.Context, req resource.UpdateRequ
 }
 
 func (t *tailnetKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
-	// Do not replace on resource creation.
-	if req.State.Raw.IsNull() {
-		return
-	}
-
 	// Do not replace on resource destroy.
 	if req.Plan.Raw.IsNull() {
 		return
 	}
 
-	var plan tailnetKeyResourceModel
+	var plan, state tailnetKeyResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Don't ever need to replace if the key is still valid.
-	if !plan.Invalid.ValueBool() {
+	t.planExpiry(ctx, req, resp, &plan, &state)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if shouldRecreateIfInvalid(plan.Reusable.ValueBool(), plan.RecreateIfInvalid.ValueString()) {
-		resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())
-		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("id"))
+	if !plan.RotateBefore.IsNull() && !plan.RotateBefore.IsUnknown() {
+		// The expiry is unknown when it is left to the API default, which is
+		// the maximum expiry.
+		var expiry types.Int64
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("expiry"), &expiry)...)
+		expirySeconds := int64(maxKeyExpiry / time.Second)
+		if !expiry.IsNull() && !expiry.IsUnknown() {
+			expirySeconds = expiry.ValueInt64()
+		}
+		if err := validateRotateBefore(plan.RotateBefore.ValueString(), expirySeconds); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("rotate_before"), "Invalid rotate_before", err.Error())
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
+
+	// Do not replace on resource creation.
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	if !plan.Keepers.Equal(state.Keepers) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("keepers"))
+	}
+
+	rotate, err := shouldRotate(state.ExpiresAt.ValueString(), plan.RotateBefore.ValueString(), time.Now())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("rotate_before"), "Failed to check key expiry", err.Error())
+		return
+	}
+
+	// Don't ever need to replace if the key is still valid and not about to expire.
+	if !rotate && (!plan.Invalid.ValueBool() || !shouldRecreateIfInvalid(plan.Reusable.ValueBool(), plan.RecreateIfInvalid.ValueString())) {
+		return
+	}
+
+	resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("id"))
+}
+
+// planExpiry sets the planned expiry in seconds from expiry_duration, if it is
+// set and has changed, and replaces the key if the expiry changes as a result.
+// The expiry for expires_at_target is only validated, as it depends on the time
+// at which the key is created, and is computed when creating the key.
+func (t *tailnetKeyResource) planExpiry(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, plan, state *tailnetKeyResourceModel) {
+	if plan.ExpiryDuration.IsUnknown() || plan.ExpiresAtTarget.IsUnknown() {
+		return
+	}
+	if plan.ExpiryDuration.IsNull() && plan.ExpiresAtTarget.IsNull() {
+		return
+	}
+
+	creating := req.State.Raw.IsNull()
+	if !creating && plan.ExpiryDuration.Equal(state.ExpiryDuration) && plan.ExpiresAtTarget.Equal(state.ExpiresAtTarget) {
+		return
+	}
+
+	expiry, err := keyExpirySeconds(plan.ExpiryDuration.ValueString(), plan.ExpiresAtTarget.ValueString(), time.Now())
+	if err != nil {
+		attribute := path.Root("expiry_duration")
+		if !plan.ExpiresAtTarget.IsNull() {
+			attribute = path.Root("expires_at_target")
+		}
+		resp.Diagnostics.AddAttributeError(attribute, "Invalid key expiry", err.Error())
+		return
+	}
+
+	if !plan.ExpiresAtTarget.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expiry"), types.Int64Unknown())...)
+		if !creating {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("expires_at_target"))
+		}
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expiry"), types.Int64Value(expiry))...)
+	if !creating && state.Expiry.ValueInt64() != expiry {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("expiry"))
+	}
+}
+
+// maxKeyExpiry is the longest expiry the API accepts for auth keys.
+const maxKeyExpiry = 90 * 24 * time.Hour
+
+// keyExpirySeconds returns the expiry in seconds of a key created at the given
+// time, given either a duration as accepted by [parseDuration] or a target
+// expiry time in RFC3339 format.
+func keyExpirySeconds(expiryDuration, expiresAtTarget string, now time.Time) (int64, error) {
+	var expiry time.Duration
+	if expiresAtTarget != "" {
+		target, err := time.Parse(time.RFC3339, expiresAtTarget)
+		if err != nil {
+			return 0, fmt.Errorf("expires_at_target must be a timestamp in RFC3339 format, e.g. 2026-01-02T15:04:05Z: %w", err)
+		}
+		expiry = target.Sub(now)
+	} else {
+		var err error
+		expiry, err = parseDuration(expiryDuration)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if expiry <= 0 {
+		return 0, fmt.Errorf("the key would expire immediately, as its expiry of %s is not in the future", expiry)
+	}
+	if expiry > maxKeyExpiry {
+		return 0, fmt.Errorf("the expiry of %s is longer than the maximum of 90 days (%d seconds)", expiry.Round(time.Second), int64(maxKeyExpiry/time.Second))
+	}
+	return int64(expiry / time.Second), nil
+}
+
+// validateRotateBefore checks that rotateBefore is shorter than the expiry of
+// the key in seconds, as the key would otherwise be replaced on every plan.
+func validateRotateBefore(rotateBefore string, expiry int64) error {
+	before, err := parseDuration(rotateBefore)
+	if err != nil {
+		return err
+	}
+	if before >= time.Duration(expiry)*time.Second {
+		return fmt.Errorf("rotate_before of %s must be shorter than the expiry of the key (%d seconds), as the key would otherwise be replaced on every plan", rotateBefore, expiry)
+	}
+	return nil
+}
+
+// shouldRotate determines if a key that expires at expiresAt, in RFC3339 format,
+// should be replaced at the given time because it expires within rotateBefore.
+// Keys are never rotated if rotateBefore or expiresAt are empty.
+func shouldRotate(expiresAt, rotateBefore string, now time.Time) (bool, error) {
+	if expiresAt == "" || rotateBefore == "" {
+		return false, nil
+	}
+
+	expires, err := time.Parse(time.RFC3339, expiresAt)
+	if err != nil {
+		return false, fmt.Errorf("invalid expiry timestamp %q: %w", expiresAt, err)
+	}
+	before, err := parseDuration(rotateBefore)
+	if err != nil {
+		return false, err
+	}
+
+	return !now.Before(expires.Add(-before)), nil
 }
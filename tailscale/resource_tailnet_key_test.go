@@ -5,14 +5,19 @@ package tailscale
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 
 	"tailscale.com/client/tailscale/v2"
 )
@@ -247,6 +252,311 @@ func TestProvider_TailscaleTailnetKeyInvalid(t *testing.T) {
 	})
 }
 
+func TestValidateRotateBefore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		rotateBefore string
+		expiry       int64
+		wantErr      bool
+	}{
+		{name: "shorter", rotateBefore: "7d", expiry: 7776000},
+		{name: "equal", rotateBefore: "1h", expiry: 3600, wantErr: true},
+		{name: "longer", rotateBefore: "2h", expiry: 3600, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRotateBefore(tt.rotateBefore, tt.expiry)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("want error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestShouldRotate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		expiresAt    string
+		rotateBefore string
+		want         bool
+	}{
+		{name: "no-rotate-before", expiresAt: "2026-01-11T00:00:00Z", want: false},
+		{name: "not-yet", expiresAt: "2026-01-20T00:00:00Z", rotateBefore: "7d", want: false},
+		{name: "within-rotate-before", expiresAt: "2026-01-15T00:00:00Z", rotateBefore: "7d", want: true},
+		{name: "exactly-rotate-before", expiresAt: "2026-01-10T12:00:00Z", rotateBefore: "12h", want: true},
+		{name: "expired", expiresAt: "2026-01-01T00:00:00Z", rotateBefore: "1h", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := shouldRotate(tt.expiresAt, tt.rotateBefore, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProvider_TailscaleTailnetKeyRotation(t *testing.T) {
+	keyExpiringIn := func(id string, expiresIn time.Duration) tailscale.Key {
+		key := testTailnetKeyStructWithID(id, true)
+		key.Expires = time.Now().Add(expiresIn).UTC().Truncate(time.Second)
+		return key
+	}
+
+	keyConfig := func(keeper string) string {
+		return fmt.Sprintf(`
+			resource "tailscale_tailnet_key" "example_key" {
+				reusable = true
+				ephemeral = true
+				preauthorized = true
+				tags = ["tag:server"]
+				expiry = 3600
+				description = "Example key"
+				rotate_before = "30m"
+				keepers = {
+					image = "%s"
+				}
+			}
+		`, keeper)
+	}
+
+	checkKeyID := func(want string) resource.TestCheckFunc {
+		return resource.TestCheckResourceAttr("tailscale_tailnet_key.example_key", "id", want)
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					testServer.SetResponses([]TestResponse{
+						{Code: http.StatusOK, Body: keyExpiringIn("old-key-id", 50*time.Minute)},
+					})
+				},
+				Config: keyConfig("image-1"),
+				Check:  checkKeyID("old-key-id"),
+			},
+			{
+				// The key now expires within rotate_before, so it is replaced.
+				PreConfig: func() {
+					testServer.SetResponses([]TestResponse{
+						{Code: http.StatusOK, Body: keyExpiringIn("old-key-id", 10*time.Minute)},
+						{Code: http.StatusOK, Body: keyExpiringIn("rotated-key-id", 50*time.Minute)},
+					})
+				},
+				Config: keyConfig("image-1"),
+				Check:  checkKeyID("rotated-key-id"),
+			},
+			{
+				// Changing the keepers replaces the key.
+				PreConfig: func() {
+					testServer.SetResponses([]TestResponse{
+						{Code: http.StatusOK, Body: keyExpiringIn("rotated-key-id", 50*time.Minute)},
+						{Code: http.StatusOK, Body: keyExpiringIn("new-image-key-id", 50*time.Minute)},
+					})
+				},
+				Config: keyConfig("image-2"),
+				Check:  checkKeyID("new-image-key-id"),
+			},
+		},
+	})
+}
+
+func TestKeyExpirySeconds(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		expiryDuration  string
+		expiresAtTarget string
+		want            int64
+		wantErr         string
+	}{
+		{name: "days", expiryDuration: "90d", want: 7776000},
+		{name: "hours", expiryDuration: "12h", want: 43200},
+		{name: "target", expiresAtTarget: "2026-01-11T00:00:00Z", want: 86400},
+		{name: "too-long", expiryDuration: "91d", wantErr: "longer than the maximum of 90 days"},
+		{name: "target-in-past", expiresAtTarget: "2026-01-01T00:00:00Z", wantErr: "is not in the future"},
+		{name: "invalid-target", expiresAtTarget: "tomorrow", wantErr: "RFC3339"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := keyExpirySeconds(tt.expiryDuration, tt.expiresAtTarget, now)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("want error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("want %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProvider_TailscaleTailnetKeyExpiryDuration(t *testing.T) {
+	keyConfig := func(expiry string) string {
+		return fmt.Sprintf(`
+			resource "tailscale_tailnet_key" "example_key" {
+				reusable = true
+				ephemeral = true
+				preauthorized = true
+				tags = ["tag:server"]
+				description = "Example key"
+				%s
+			}
+		`, expiry)
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.SetResponses([]TestResponse{
+				{Code: http.StatusOK, Body: testTailnetKeyStructWithID("old-key-id", true)},
+			})
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: keyConfig("expiry = 3600"),
+				Check:  resource.TestCheckResourceAttr("tailscale_tailnet_key.example_key", "expiry", "3600"),
+			},
+			{
+				// Switching to an equivalent expiry_duration does not replace the key.
+				Config: keyConfig(`expiry_duration = "1h"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("tailscale_tailnet_key.example_key", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tailscale_tailnet_key.example_key", "id", "old-key-id"),
+					resource.TestCheckResourceAttr("tailscale_tailnet_key.example_key", "expiry", "3600"),
+				),
+			},
+			{
+				Config:      keyConfig(`expiry_duration = "100d"`),
+				ExpectError: regexp.MustCompile(`longer\s+than\s+the\s+maximum\s+of\s+90\s+days`),
+			},
+		},
+	})
+}
+
+func TestProvider_TailscaleTailnetKeyExpiresAtTarget(t *testing.T) {
+	target := time.Now().Add(2 * time.Hour).UTC().Format(time.RFC3339)
+	var expirySeconds int64
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.HandleRequest = func(method, path string) TestResponse {
+				if method == http.MethodPost {
+					var body tailscale.CreateKeyRequest
+					if err := json.Unmarshal(testServer.Body.Bytes(), &body); err != nil {
+						return TestResponse{Code: http.StatusBadRequest, Body: map[string]any{"message": err.Error()}}
+					}
+					expirySeconds = body.ExpirySeconds
+				}
+				return TestResponse{Code: http.StatusOK, Body: testTailnetKeyStructWithID("target-key-id", true)}
+			}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				// The expiry is computed when the key is created, so that the
+				// key expires at the target regardless of when the plan was made.
+				Config: fmt.Sprintf(`
+					resource "tailscale_tailnet_key" "example_key" {
+						reusable          = true
+						ephemeral         = true
+						preauthorized     = true
+						tags              = ["tag:server"]
+						description       = "Example key"
+						expires_at_target = %q
+					}`, target),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("tailscale_tailnet_key.example_key", tfjsonpath.New("expiry")),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tailscale_tailnet_key.example_key", "id", "target-key-id"),
+					func(_ *terraform.State) error {
+						if expirySeconds <= 0 || expirySeconds > 7200 {
+							return fmt.Errorf("want an expiry of at most 7200 seconds to be requested, got %d", expirySeconds)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestProvider_TailscaleTailnetKeyExpiryConflicts(t *testing.T) {
+	testCases := []expectedErrorTestCase{
+		{
+			Name: "expiry-and-expiry-duration",
+			Config: `
+				resource "tailscale_tailnet_key" "example" {
+					expiry          = 3600
+					expiry_duration = "1h"
+				}
+			`,
+			ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+		},
+		{
+			Name: "invalid-expiry-duration",
+			Config: `
+				resource "tailscale_tailnet_key" "example" {
+					expiry_duration = "a week"
+				}
+			`,
+			ExpectError: regexp.MustCompile(`value must be a positive duration`),
+		},
+		{
+			Name: "rotate-before-expiry",
+			Config: `
+				resource "tailscale_tailnet_key" "example" {
+					expiry_duration = "7d"
+					rotate_before   = "7d"
+				}
+			`,
+			ExpectError: regexp.MustCompile(`rotate_before\s+of\s+7d\s+must\s+be\s+shorter\s+than\s+the\s+expiry`),
+		},
+		{
+			Name: "rotate-before-default-expiry",
+			Config: `
+				resource "tailscale_tailnet_key" "example" {
+					rotate_before = "91d"
+				}
+			`,
+			ExpectError: regexp.MustCompile(`must\s+be\s+shorter\s+than\s+the\s+expiry\s+of\s+the\s+key\s+\(7776000\s+seconds\)`),
+		},
+	}
+
+	runExpectedErrorTests(t, testCases)
+}
+
 func TestAccTailscaleTailnetKey(t *testing.T) {
 	const resourceName = "tailscale_tailnet_key.test_key"
 
@@ -0,0 +1,263 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"tailscale.com/client/tailscale/v2"
+)
+
+const resourceUserInviteDescription = `The user_invite resource invites a user to join the tailnet. See https://tailscale.com/kb/1371/invite-users for more information.
+
+The invite URL can be sent to the user, or the invite can be emailed to them by setting email. Invites no longer exist once they have been accepted. If email is set, the invite is then reported as accepted if a user with that login name has joined the tailnet, and the ID of that user is recorded in user_id; otherwise the invite is considered revoked and is created again. Invites without an email are reported as accepted once they no longer exist.
+
+Destroying the resource deletes the invite if it is still pending. Users who have already accepted the invite are not removed from the tailnet.
+`
+
+var (
+	_ resource.Resource                = &userInviteResource{}
+	_ resource.ResourceWithConfigure   = &userInviteResource{}
+	_ resource.ResourceWithImportState = &userInviteResource{}
+)
+
+// userInvite is an invite to join the tailnet, as returned by the user
+// invites API.
+type userInvite struct {
+	ID        string `json:"id"`
+	Role      string `json:"role"`
+	Email     string `json:"email"`
+	InviteURL string `json:"inviteUrl"`
+}
+
+// createUserInviteRequest is the request body for a single invite when
+// creating user invites.
+type createUserInviteRequest struct {
+	Role  string `json:"role"`
+	Email string `json:"email,omitempty"`
+}
+
+type userInviteResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Role      types.String `tfsdk:"role"`
+	Email     types.String `tfsdk:"email"`
+	InviteURL types.String `tfsdk:"invite_url"`
+	Accepted  types.Bool   `tfsdk:"accepted"`
+	UserID    types.String `tfsdk:"user_id"`
+}
+
+// NewUserInviteResource returns a new user invite resource.
+func NewUserInviteResource() resource.Resource {
+	return &userInviteResource{}
+}
+
+type userInviteResource struct {
+	ResourceBase
+	ResourceImportedByID
+}
+
+func (r userInviteResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_invite"
+}
+
+func (r userInviteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: resourceUserInviteDescription,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the invite",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The role the user is assigned when accepting the invite. Valid values are `owner`, `member`, `admin`, `it-admin`, `network-admin`, `billing-admin`, and `auditor`. Defaults to `member`.",
+				Default:     stringdefault.StaticString(string(tailscale.UserRoleMember)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(userRoles...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"email": schema.StringAttribute{
+				Optional:    true,
+				Description: "The email address to send the invite to. If not set, the invite URL must be shared with the user manually.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"invite_url": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The URL at which the invite can be accepted",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"accepted": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the invite has been accepted",
+			},
+			"user_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the user who accepted the invite. Only known for invites with an email.",
+			},
+		},
+	}
+}
+
+func (r userInviteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan userInviteResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request := []createUserInviteRequest{{
+		Role:  plan.Role.ValueString(),
+		Email: plan.Email.ValueString(),
+	}}
+
+	var invites []userInvite
+	if err := apiRequest(ctx, r.Client, http.MethodPost, request, &invites, tailnetPath(r.Client, "user-invites")...); err != nil {
+		resp.Diagnostics.AddError("Failed to create user invite", err.Error())
+		return
+	}
+	if len(invites) != 1 {
+		resp.Diagnostics.AddError("Failed to create user invite", "Expected the API to return exactly one invite")
+		return
+	}
+
+	plan.ID = types.StringValue(invites[0].ID)
+	plan.InviteURL = types.StringValue(invites[0].InviteURL)
+	plan.Accepted = types.BoolValue(false)
+	plan.UserID = types.StringNull()
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r userInviteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state userInviteResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Accepted.ValueBool() {
+		return
+	}
+
+	inviteID := state.ID.ValueString()
+
+	var invite userInvite
+	err := apiRequest(ctx, r.Client, http.MethodGet, nil, &invite, "user-invites", inviteID)
+	switch {
+	case tailscale.IsNotFound(err):
+		user, err := r.findInvitedUser(ctx, state.Email.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to fetch users", err.Error())
+			return
+		}
+		if user == nil && !state.Email.IsNull() {
+			// The invite has been revoked, so remove it from the state so it is created again.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		state.Accepted = types.BoolValue(true)
+		if user != nil {
+			state.UserID = types.StringValue(user.ID)
+		}
+	case err != nil:
+		resp.Diagnostics.AddError(
+			"Failed to fetch user invite",
+			"Failed to fetch user invite with ID "+inviteID+": "+err.Error(),
+		)
+		return
+	default:
+		state.Role = types.StringValue(invite.Role)
+		state.Email = StringValueNullIfEmpty(invite.Email)
+		state.InviteURL = types.StringValue(invite.InviteURL)
+		state.Accepted = types.BoolValue(false)
+		state.UserID = types.StringNull()
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r userInviteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All configurable attributes require the invite to be replaced, so the
+	// plan only carries over the computed attributes from the state.
+	var plan userInviteResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r userInviteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state userInviteResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Accepted.ValueBool() {
+		return
+	}
+
+	inviteID := state.ID.ValueString()
+
+	err := apiRequest(ctx, r.Client, http.MethodDelete, nil, nil, "user-invites", inviteID)
+	// The invite may have been accepted or deleted since it was last read, so we can ignore not-found errors.
+	if err != nil && !tailscale.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Failed to delete user invite",
+			"Failed to delete user invite with ID "+inviteID+": "+err.Error(),
+		)
+	}
+}
+
+// findInvitedUser returns the user whose login name matches the email the
+// invite was sent to, or nil if there is no such user or no email was given.
+func (r userInviteResource) findInvitedUser(ctx context.Context, email string) (*tailscale.User, error) {
+	if email == "" {
+		return nil, nil
+	}
+
+	users, err := r.Client.Users().List(ctx, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		if strings.EqualFold(user.LoginName, email) {
+			return &user, nil
+		}
+	}
+	return nil, nil
+}
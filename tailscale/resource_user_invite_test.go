@@ -0,0 +1,67 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"tailscale.com/client/tailscale/v2"
+)
+
+func TestProvider_TailscaleUserInvite(t *testing.T) {
+	const testUserInvite = `
+		resource "tailscale_user_invite" "contractor" {
+			email = "contractor@example.com"
+		}`
+
+	invite := userInvite{
+		ID:        "invite1",
+		Role:      "member",
+		Email:     "contractor@example.com",
+		InviteURL: "https://login.tailscale.com/admin/invite/abc",
+	}
+	accepted := false
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.HandleRequest = func(method, path string) TestResponse {
+				switch {
+				case method == http.MethodPost:
+					return TestResponse{Code: http.StatusOK, Body: []userInvite{invite}}
+				case strings.HasSuffix(path, "/users"):
+					return TestResponse{Code: http.StatusOK, Body: map[string][]tailscale.User{
+						"users": {{ID: "user1", LoginName: "Contractor@example.com"}},
+					}}
+				case accepted:
+					return TestResponse{Code: http.StatusNotFound, Body: map[string]string{"message": "not found"}}
+				}
+				return TestResponse{Code: http.StatusOK, Body: invite}
+			}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testUserInvite,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tailscale_user_invite.contractor", "id", "invite1"),
+					resource.TestCheckResourceAttr("tailscale_user_invite.contractor", "role", "member"),
+					resource.TestCheckResourceAttr("tailscale_user_invite.contractor", "invite_url", invite.InviteURL),
+					resource.TestCheckResourceAttr("tailscale_user_invite.contractor", "accepted", "false"),
+				),
+			},
+			{
+				PreConfig: func() { accepted = true },
+				Config:    testUserInvite,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tailscale_user_invite.contractor", "accepted", "true"),
+					resource.TestCheckResourceAttr("tailscale_user_invite.contractor", "user_id", "user1"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,205 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"tailscale.com/client/tailscale/v2"
+)
+
+const resourceUserRoleDescription = `The user_role resource manages the role of a user in the tailnet. See https://tailscale.com/kb/1138/user-roles for more information.
+
+The role the user had before the resource was created is recorded in previous_role, and is restored when the resource is destroyed.
+`
+
+var (
+	_ resource.Resource                = &userRoleResource{}
+	_ resource.ResourceWithConfigure   = &userRoleResource{}
+	_ resource.ResourceWithImportState = &userRoleResource{}
+)
+
+type userRoleResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	UserID       types.String `tfsdk:"user_id"`
+	Role         types.String `tfsdk:"role"`
+	PreviousRole types.String `tfsdk:"previous_role"`
+}
+
+// NewUserRoleResource returns a new user role resource.
+func NewUserRoleResource() resource.Resource {
+	return &userRoleResource{}
+}
+
+type userRoleResource struct {
+	ResourceBase
+	ResourceImportedByID
+}
+
+func (r userRoleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_role"
+}
+
+func (r userRoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: resourceUserRoleDescription,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the user",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Required:    true,
+				Description: "The role of the user. Valid values are `owner`, `member`, `admin`, `it-admin`, `network-admin`, `billing-admin`, and `auditor`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(userRoles...),
+				},
+			},
+			"previous_role": schema.StringAttribute{
+				Computed:    true,
+				Description: "The role of the user before the resource was created, which is restored when the resource is destroyed. Not set for imported resources.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r userRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan userRoleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := plan.UserID.ValueString()
+
+	user, err := r.Client.Users().Get(ctx, userID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to fetch user",
+			"Failed to fetch user with ID "+userID+": "+err.Error(),
+		)
+		return
+	}
+
+	if err := r.setRole(ctx, userID, plan.Role.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to set user role",
+			"Failed to set role for user with ID "+userID+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(userID)
+	plan.PreviousRole = types.StringValue(string(user.Role))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r userRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state userRoleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := state.ID.ValueString()
+
+	user, err := r.Client.Users().Get(ctx, userID)
+	if err != nil {
+		// If the user is not found, remove from the state so we can create it again.
+		if tailscale.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Failed to fetch user",
+			"Failed to fetch user with ID "+userID+": "+err.Error(),
+		)
+		return
+	}
+
+	state.UserID = types.StringValue(user.ID)
+	state.Role = types.StringValue(string(user.Role))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r userRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan userRoleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := plan.UserID.ValueString()
+
+	if err := r.setRole(ctx, userID, plan.Role.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to set user role",
+			"Failed to set role for user with ID "+userID+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r userRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state userRoleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The previous role is unknown for imported resources, in which case the
+	// role is left as it is.
+	previousRole := state.PreviousRole.ValueString()
+	if previousRole == "" || previousRole == state.Role.ValueString() {
+		return
+	}
+
+	userID := state.UserID.ValueString()
+
+	err := r.setRole(ctx, userID, previousRole)
+	// The user may already have been deleted, so we can ignore not-found errors.
+	if err != nil && !tailscale.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Failed to set user role",
+			"Failed to restore role for user with ID "+userID+": "+err.Error(),
+		)
+	}
+}
+
+func (r userRoleResource) setRole(ctx context.Context, userID, role string) error {
+	body := map[string]string{"role": role}
+	return apiRequest(ctx, r.Client, http.MethodPost, body, nil, "users", userID, "role")
+}
@@ -0,0 +1,77 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"tailscale.com/client/tailscale/v2"
+)
+
+func TestProvider_TailscaleUserRole(t *testing.T) {
+	const testUserRole = `
+		resource "tailscale_user_role" "oncall" {
+			user_id = "user1"
+			role    = "admin"
+		}`
+
+	user := tailscale.User{ID: "user1", Role: tailscale.UserRoleMember}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.HandleRequest = func(method, path string) TestResponse {
+				if method == http.MethodPost {
+					user.Role = tailscale.UserRoleAdmin
+					return TestResponse{Code: http.StatusOK, Body: map[string]any{}}
+				}
+				return TestResponse{Code: http.StatusOK, Body: user}
+			}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		CheckDestroy: func(_ *terraform.State) error {
+			var body map[string]string
+			if err := json.Unmarshal(testServer.Body.Bytes(), &body); err != nil {
+				return err
+			}
+			if testServer.Path != "/api/v2/users/user1/role" || body["role"] != "member" {
+				return fmt.Errorf("want previous role to be restored, got last request %s %s %v", testServer.Method, testServer.Path, body)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testUserRole,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tailscale_user_role.oncall", "id", "user1"),
+					resource.TestCheckResourceAttr("tailscale_user_role.oncall", "role", "admin"),
+					resource.TestCheckResourceAttr("tailscale_user_role.oncall", "previous_role", "member"),
+				),
+			},
+		},
+	})
+}
+
+func TestProvider_TailscaleUserRole_InvalidRole(t *testing.T) {
+	testCases := []expectedErrorTestCase{
+		{
+			Name: "invalid-role",
+			Config: `
+				resource "tailscale_user_role" "example" {
+					user_id = "user1"
+					role    = "superuser"
+				}
+			`,
+			ExpectError: regexp.MustCompile(`Attribute role value must be one of`),
+		},
+	}
+
+	runExpectedErrorTests(t, testCases)
+}
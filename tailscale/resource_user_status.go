@@ -0,0 +1,235 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"tailscale.com/client/tailscale/v2"
+)
+
+const resourceUserStatusDescription = `The user_status resource approves, suspends or restores a user in the tailnet. See https://tailscale.com/kb/1239/user-approval and https://tailscale.com/kb/1145/edit-user-access for more information.
+
+Setting status to active approves users that are waiting for approval and restores suspended users. Setting status to suspended suspends the user. When the resource is destroyed, a user that was suspended by this resource is restored, and a suspended user that was restored by this resource is suspended again. Approved users cannot be returned to pending approval.
+`
+
+var (
+	_ resource.Resource                = &userStatusResource{}
+	_ resource.ResourceWithConfigure   = &userStatusResource{}
+	_ resource.ResourceWithImportState = &userStatusResource{}
+)
+
+type userStatusResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	UserID         types.String `tfsdk:"user_id"`
+	Status         types.String `tfsdk:"status"`
+	PreviousStatus types.String `tfsdk:"previous_status"`
+}
+
+// NewUserStatusResource returns a new user status resource.
+func NewUserStatusResource() resource.Resource {
+	return &userStatusResource{}
+}
+
+type userStatusResource struct {
+	ResourceBase
+	ResourceImportedByID
+}
+
+func (r userStatusResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_status"
+}
+
+func (r userStatusResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: resourceUserStatusDescription,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the user",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Required:    true,
+				Description: "The desired status of the user. Valid values are `active` and `suspended`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(tailscale.UserStatusActive),
+						string(tailscale.UserStatusSuspended),
+					),
+				},
+			},
+			"previous_status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The status of the user before the resource was created. Not set for imported resources.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r userStatusResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan userStatusResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := plan.UserID.ValueString()
+
+	user, err := r.Client.Users().Get(ctx, userID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to fetch user",
+			"Failed to fetch user with ID "+userID+": "+err.Error(),
+		)
+		return
+	}
+
+	if err := r.setStatus(ctx, userID, user.Status, plan.Status.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to set user status",
+			"Failed to set status for user with ID "+userID+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(userID)
+	plan.PreviousStatus = types.StringValue(userStatus(user.Status))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r userStatusResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state userStatusResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := state.ID.ValueString()
+
+	user, err := r.Client.Users().Get(ctx, userID)
+	if err != nil {
+		// If the user is not found, remove from the state so we can create it again.
+		if tailscale.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Failed to fetch user",
+			"Failed to fetch user with ID "+userID+": "+err.Error(),
+		)
+		return
+	}
+
+	state.UserID = types.StringValue(user.ID)
+	state.Status = types.StringValue(userStatus(user.Status))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r userStatusResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state userStatusResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := plan.UserID.ValueString()
+
+	err := r.setStatus(ctx, userID, tailscale.UserStatus(state.Status.ValueString()), plan.Status.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to set user status",
+			"Failed to set status for user with ID "+userID+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r userStatusResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state userStatusResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Users that were pending approval cannot be returned to that status, and
+	// the previous status is unknown for imported resources.
+	previousStatus := state.PreviousStatus.ValueString()
+	if previousStatus != string(tailscale.UserStatusActive) && previousStatus != string(tailscale.UserStatusSuspended) {
+		return
+	}
+
+	userID := state.UserID.ValueString()
+
+	err := r.setStatus(ctx, userID, tailscale.UserStatus(state.Status.ValueString()), previousStatus)
+	// The user may already have been deleted, so we can ignore not-found errors.
+	if err != nil && !tailscale.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Failed to set user status",
+			"Failed to restore status for user with ID "+userID+": "+err.Error(),
+		)
+	}
+}
+
+// setStatus approves, suspends or restores the user as needed to move it from
+// the current status to the desired status.
+func (r userStatusResource) setStatus(ctx context.Context, userID string, current tailscale.UserStatus, desired string) error {
+	var action string
+	switch {
+	case desired == string(tailscale.UserStatusSuspended) && current != tailscale.UserStatusSuspended:
+		action = "suspend"
+	case desired == string(tailscale.UserStatusActive) && current == tailscale.UserStatusSuspended:
+		action = "restore"
+	case desired == string(tailscale.UserStatusActive) && current == tailscale.UserStatusNeedsApproval:
+		action = "approve"
+	default:
+		return nil
+	}
+
+	return apiRequest(ctx, r.Client, http.MethodPost, nil, nil, "users", userID, action)
+}
+
+// userStatus returns the status of the user as it is configured in the
+// user_status resource. Users that are idle or over the billing limit are
+// still active, in that they are neither suspended nor pending approval.
+func userStatus(status tailscale.UserStatus) string {
+	switch status {
+	case tailscale.UserStatusSuspended, tailscale.UserStatusNeedsApproval:
+		return string(status)
+	default:
+		return string(tailscale.UserStatusActive)
+	}
+}
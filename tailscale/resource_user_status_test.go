@@ -0,0 +1,73 @@
+// Copyright (c) David Bond, Tailscale Inc, & Contributors
+// SPDX-License-Identifier: MIT
+
+package tailscale
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"tailscale.com/client/tailscale/v2"
+)
+
+func TestUserStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := map[tailscale.UserStatus]string{
+		tailscale.UserStatusActive:           "active",
+		tailscale.UserStatusIdle:             "active",
+		tailscale.UserStatusOverBillingLimit: "active",
+		tailscale.UserStatusSuspended:        "suspended",
+		tailscale.UserStatusNeedsApproval:    "needs-approval",
+	}
+
+	for status, want := range tests {
+		if got := userStatus(status); got != want {
+			t.Errorf("userStatus(%q): want %q, got %q", status, want, got)
+		}
+	}
+}
+
+func TestProvider_TailscaleUserStatus(t *testing.T) {
+	const testUserStatus = `
+		resource "tailscale_user_status" "contractor" {
+			user_id = "user1"
+			status  = "suspended"
+		}`
+
+	user := tailscale.User{ID: "user1", Status: tailscale.UserStatusIdle}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		PreCheck: func() {
+			testServer.HandleRequest = func(method, path string) TestResponse {
+				switch path {
+				case "/api/v2/users/user1/suspend":
+					user.Status = tailscale.UserStatusSuspended
+				case "/api/v2/users/user1/restore":
+					user.Status = tailscale.UserStatusActive
+				}
+				return TestResponse{Code: http.StatusOK, Body: user}
+			}
+		},
+		ProtoV5ProviderFactories: testProviderFactories(t),
+		CheckDestroy: func(_ *terraform.State) error {
+			if testServer.Method != http.MethodPost || testServer.Path != "/api/v2/users/user1/restore" {
+				return fmt.Errorf("want user to be restored, got last request %s %s", testServer.Method, testServer.Path)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testUserStatus,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tailscale_user_status.contractor", "status", "suspended"),
+					resource.TestCheckResourceAttr("tailscale_user_status.contractor", "previous_status", "active"),
+				),
+			},
+		},
+	})
+}
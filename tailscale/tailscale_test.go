@@ -79,11 +79,12 @@ func (t *TestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	t.Method = r.Method
 	t.Path = r.URL.Path
 
-	resp := t.HandleRequest(r.Method, t.Path)
-
 	t.Body = bytes.NewBuffer([]byte{})
 	_, err := io.Copy(t.Body, r.Body)
 	assert.NoError(t.t, err)
+
+	resp := t.HandleRequest(r.Method, t.Path)
+
 	w.WriteHeader(resp.Code)
 	switch body := resp.Body.(type) {
 	case []byte:
@@ -6,6 +6,7 @@ package tailscale
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -40,6 +41,60 @@ func TestCIDRValidator(t *testing.T) {
 	runStringValidatorTests(t, cidrValidator{}, testCases)
 }
 
+func TestIPAddressValidator(t *testing.T) {
+	testCases := []stringValidatorTestCase{
+		{
+			name:   "valid-ipv4",
+			config: types.StringValue("100.64.0.1"),
+		},
+		{
+			name:   "valid-ipv6",
+			config: types.StringValue("fd7a:115c:a1e0::1"),
+		},
+		{
+			name:   "ipv4-mapped-ipv6",
+			config: types.StringValue("::ffff:100.64.0.1"),
+		},
+		{
+			name:   "zoned-ipv6",
+			config: types.StringValue("fe80::1%eth0"),
+		},
+		{
+			name:    "leading-zeros",
+			config:  types.StringValue("100.064.0.1"),
+			wantErr: true,
+		},
+		{
+			name:    "cidr",
+			config:  types.StringValue("100.64.0.1/32"),
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			config:  types.StringValue(""),
+			wantErr: true,
+		},
+	}
+
+	runStringValidatorTests(t, ipAddressValidator{}, testCases)
+}
+
+func TestRegexpValidator(t *testing.T) {
+	testCases := []stringValidatorTestCase{
+		{
+			name:   "valid-regexp",
+			config: types.StringValue(`^ci-runner-\d+$`),
+		},
+		{
+			name:    "invalid-regexp",
+			config:  types.StringValue(`ci-runner-(`),
+			wantErr: true,
+		},
+	}
+
+	runStringValidatorTests(t, regexpValidator{}, testCases)
+}
+
 func TestRetryDeadlineValidator(t *testing.T) {
 	testCases := []stringValidatorTestCase{
 		{
@@ -66,6 +121,60 @@ func TestRetryDeadlineValidator(t *testing.T) {
 	runStringValidatorTests(t, retryDeadlineValidator{}, testCases)
 }
 
+func TestDurationValidator(t *testing.T) {
+	testCases := []stringValidatorTestCase{
+		{
+			name:   "valid-hours",
+			config: types.StringValue("12h"),
+		},
+		{
+			name:   "valid-days",
+			config: types.StringValue("90d"),
+		},
+		{
+			name:    "invalid-duration",
+			config:  types.StringValue("abc"),
+			wantErr: true,
+		},
+		{
+			name:    "fractional-days",
+			config:  types.StringValue("1.5d"),
+			wantErr: true,
+		},
+		{
+			name:    "zero",
+			config:  types.StringValue("0d"),
+			wantErr: true,
+		},
+		{
+			name:    "negative",
+			config:  types.StringValue("-1h"),
+			wantErr: true,
+		},
+	}
+
+	runStringValidatorTests(t, durationValidator{}, testCases)
+}
+
+func TestParseDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]time.Duration{
+		"90d":   90 * 24 * time.Hour,
+		"2160h": 90 * 24 * time.Hour,
+		"1h30m": 90 * time.Minute,
+	}
+
+	for s, want := range tests {
+		got, err := parseDuration(s)
+		if err != nil {
+			t.Errorf("parseDuration(%q): unexpected error: %v", s, err)
+		} else if got != want {
+			t.Errorf("parseDuration(%q): want %v, got %v", s, want, got)
+		}
+	}
+}
+
 func TestAclHuJSONValidator(t *testing.T) {
 	testCases := []stringValidatorTestCase{
 		{
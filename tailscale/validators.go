@@ -7,6 +7,10 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
@@ -18,7 +22,10 @@ import (
 
 var (
 	_ validator.String = cidrValidator{}
+	_ validator.String = ipAddressValidator{}
+	_ validator.String = regexpValidator{}
 	_ validator.String = retryDeadlineValidator{}
+	_ validator.String = durationValidator{}
 	_ validator.String = aclHuJSONValidator{}
 	_ validator.List   = atLeastOneBlockRequiredListValidator{}
 	_ validator.Set    = exactlyOneBlockRequiredSetValidator{}
@@ -51,6 +58,56 @@ func (v cidrValidator) ValidateString(ctx context.Context, req validator.StringR
 	}
 }
 
+// ipAddressValidator is a [validator.String] for IPv4 and IPv6 addresses.
+type ipAddressValidator struct{}
+
+func (v ipAddressValidator) Description(_ context.Context) string {
+	return "value must be an IP address"
+}
+
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if _, err := netip.ParseAddr(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			req.Path,
+			v.Description(ctx),
+			req.ConfigValue.ValueString(),
+		))
+	}
+}
+
+// regexpValidator is a [validator.String] for regular expressions.
+type regexpValidator struct{}
+
+func (v regexpValidator) Description(_ context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v regexpValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexpValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			req.Path,
+			fmt.Sprintf("%s: %s", v.Description(ctx), err),
+			req.ConfigValue.ValueString(),
+		))
+	}
+}
+
 // retryDeadlineValdiator is a [validator.String] that checks whether a string can be
 // parsed as a duration greater than 1s.
 type retryDeadlineValidator struct{}
@@ -85,6 +142,45 @@ func (r retryDeadlineValidator) ValidateString(_ context.Context, req validator.
 	}
 }
 
+// durationValidator is a [validator.String] that checks whether a string can be
+// parsed by [parseDuration] as a positive duration.
+type durationValidator struct{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a positive duration, e.g. 12h or 30d"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if d, err := parseDuration(req.ConfigValue.ValueString()); err != nil || d <= 0 {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			req.Path,
+			v.Description(ctx),
+			req.ConfigValue.ValueString(),
+		))
+	}
+}
+
+// parseDuration parses a duration in the format accepted by
+// [time.ParseDuration], or a whole number of days such as "90d".
+func parseDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseInt(days, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
 // aclHuJSONValidator is a [validator.String] that checks whether a string can be
 // parsed as HuJSON.
 type aclHuJSONValidator struct{}